@@ -1,7 +1,7 @@
 
 import json
 import click
-from .commands import build_application_package, create_client_app_package, create_resources_archive
+from .commands import build_application_package, create_client_app_package, create_resources_archive, export_parameter_hints
 
 
 @click.group()
@@ -30,6 +30,7 @@ def package():
     pass
 
 package.add_command(build_application_package)
+package.add_command(export_parameter_hints)
 
 if __name__ == '__main__':
     cli()
\ No newline at end of file
@@ -10,6 +10,9 @@ from modm import _zip_utils
 from modm.marketplace.application_packaging_options import ApplicationPackageOptions
 from modm.marketplace.application_package_info import ApplicationPackageInfo
 from modm.marketplace.application_package import ApplicationPackage
+from modm.marketplace.parameter_hints import ParameterHints
+from modm.arm.api_version_policy import ApiVersionPolicy
+from modm.arm.resource_policy import ResourcePolicy
 from modm.installer.client_app_package import ClientAppPackage
 from modm.release.version import Version
 
@@ -29,6 +32,9 @@ from modm.release.version import Version
 @click.option("-f", "--main-template", help="The path to the application's main template.", required=True)
 @click.option("--create-ui-definition", help="The path to the createUiDefinition.json file", required=True)
 @click.option("-o", "--out-dir", help="The location where the application package will be created", required=True)
+@click.option("--api-version-policy", default=None, help="The path to a policy file the apiVersions of an ARM template are validated with.")
+@click.option("--partner-id", default=None, help="The partner's customer usage attribution GUID, sent with the deployment's requests.")
+@click.option("--resource-policy", default=None, help="The path to a policy file with the required tags and naming conventions the resources of an ARM template are validated with.")
 @click.argument("current_working_dir", type=click.Path(exists=True))
 def build_application_package(
     name,
@@ -41,6 +47,9 @@ def build_application_package(
     create_ui_definition,
     current_working_dir,
     out_dir=None,
+    api_version_policy=None,
+    partner_id=None,
+    resource_policy=None,
 ):
     """Builds an application package and produces an app.zip"""
     cwd = Path(current_working_dir)
@@ -52,7 +61,15 @@ def build_application_package(
     if resources_file is not None:
         resources_file = cwd.joinpath(resources_file).resolve()
 
-    info = ApplicationPackageInfo(resolved_template_file, resolved_create_ui_definition, name, description)
+    if api_version_policy is not None:
+        api_version_policy = ApiVersionPolicy.from_file(cwd.joinpath(api_version_policy).resolve())
+
+    if resource_policy is not None:
+        resource_policy = ResourcePolicy.from_file(cwd.joinpath(resource_policy).resolve())
+
+    info = ApplicationPackageInfo(
+        resolved_template_file, resolved_create_ui_definition, name, description, api_version_policy, partner_id, resource_policy
+    )
     options = ApplicationPackageOptions(version, vmi_reference, vmi_reference_id, resources_file, out_dir)
 
     package = ApplicationPackage(info)
@@ -61,6 +78,22 @@ def build_application_package(
     click.echo(json.dumps(result.serialize(), indent=2))
 
 
+@click.help_option("-h", "--help")
+@click.command("parameters")
+@click.option("-f", "--main-template", help="The path to the application's main template.", required=True)
+@click.option("--create-ui-definition", help="The path to the createUiDefinition.json file", required=True)
+@click.argument("current_working_dir", type=click.Path(exists=True))
+def export_parameter_hints(main_template, create_ui_definition, current_working_dir):
+    """Exports the application's template parameters with their constraints and createUiDefinition mappings as json"""
+    cwd = Path(current_working_dir)
+
+    resolved_template_file = cwd.joinpath(main_template).resolve()
+    resolved_create_ui_definition = cwd.joinpath(create_ui_definition).resolve()
+
+    info = ApplicationPackageInfo(resolved_template_file, resolved_create_ui_definition)
+    click.echo(ParameterHints(info).to_json())
+
+
 @click.help_option("-h", "--help")
 @click.command("create-client-app-package")
 @click.option("-f", "--csproj-file", help="Path to the .csproj file of the client app", required=True)
@@ -0,0 +1,129 @@
+import json
+import os
+import re
+from datetime import date
+from .arm_template import ArmTemplate
+
+
+class ApiVersionPolicyMode:
+    error = "error"
+    warn = "warn"
+
+
+class ApiVersionPolicy:
+    """
+    Validates the apiVersion of every resource in an ARM template against allow and deny lists and a maximum age,
+    so deprecated API versions are caught at packaging time rather than when a customer deploys.
+
+    Example policy file:
+    {
+        "mode": "error",
+        "maxAgeDays": 730,
+        "allow": { "Microsoft.Storage/storageAccounts": ["2023-01-01"] },
+        "deny": { "Microsoft.Web/sites": ["2016-08-01"] }
+    }
+
+    Resource types in the allow list must use one of the listed versions. Versions in the deny list are never valid.
+    """
+
+    def __init__(self, mode: str = ApiVersionPolicyMode.error, allow: dict = None, deny: dict = None, max_age_days: int = None, today: date = None):
+        if mode not in [ApiVersionPolicyMode.error, ApiVersionPolicyMode.warn]:
+            raise ValueError(f"Unsupported api version policy mode {mode}")
+
+        self.mode = mode
+        self.allow = self._normalize(allow)
+        self.deny = self._normalize(deny)
+        self.max_age_days = max_age_days
+        self.today = today or date.today()
+
+    @property
+    def is_enforced(self) -> bool:
+        """Whether violations fail validation, otherwise they are reported as warnings"""
+        return self.mode == ApiVersionPolicyMode.error
+
+    def validate(self, template: ArmTemplate) -> list[ValueError]:
+        results = []
+
+        for resource_type, api_version in self._get_resources(template.document):
+            message = self._evaluate(resource_type, api_version)
+
+            if message is not None:
+                results.append(ValueError({"message": message, "properties": [f"{resource_type}@{api_version}"]}))
+
+        return results
+
+    def _evaluate(self, resource_type: str, api_version: str):
+        key = resource_type.lower()
+
+        if api_version in self.deny.get(key, []):
+            return f"The apiVersion {api_version} of {resource_type} is denied by policy."
+
+        if key in self.allow and api_version not in self.allow[key]:
+            return f"The apiVersion {api_version} of {resource_type} is not allowed by policy. Allowed: {', '.join(self.allow[key])}."
+
+        if self.max_age_days is not None:
+            released = self._get_release_date(api_version)
+
+            if released is not None and (self.today - released).days > self.max_age_days:
+                return f"The apiVersion {api_version} of {resource_type} is older than {self.max_age_days} days."
+
+        return None
+
+    def _get_resources(self, document: dict, parent_type: str = None):
+        """
+        Returns (type, apiVersion) of all resources, including child resources and nested deployment templates.
+        Child resources declare their type relative to the parent, e.g. config of Microsoft.Web/sites, so it's composed
+        into the full type, e.g. Microsoft.Web/sites/config
+        """
+        resources = document.get("resources", [])
+
+        # languageVersion 2.0 templates declare resources by symbolic name
+        if isinstance(resources, dict):
+            resources = list(resources.values())
+
+        for resource in resources:
+            resource_type = resource.get("type")
+            api_version = resource.get("apiVersion")
+
+            if isinstance(resource_type, str) and parent_type is not None and not self._is_full_type(resource_type):
+                resource_type = f"{parent_type}/{resource_type}"
+
+            # expressions can't be evaluated at packaging time
+            if isinstance(resource_type, str) and isinstance(api_version, str) and not api_version.startswith("["):
+                yield resource_type, api_version
+
+            if isinstance(resource_type, str):
+                yield from self._get_resources(resource, resource_type)
+
+            nested_template = resource.get("properties", {}).get("template")
+            if isinstance(nested_template, dict):
+                yield from self._get_resources(nested_template)
+
+    def _is_full_type(self, resource_type: str):
+        """Whether the type starts with the resource provider namespace, e.g. Microsoft.Web/sites/config"""
+        return "/" in resource_type and "." in resource_type.split("/")[0]
+
+    def _get_release_date(self, api_version: str):
+        match = re.match(r"^(\d{4})-(\d{2})-(\d{2})", api_version)
+
+        if match is None:
+            return None
+
+        return date(int(match.group(1)), int(match.group(2)), int(match.group(3)))
+
+    def _normalize(self, versions: dict):
+        return {resource_type.lower(): list(api_versions) for resource_type, api_versions in (versions or {}).items()}
+
+    @staticmethod
+    def from_file(file_path):
+        if not os.path.exists(file_path):
+            raise FileNotFoundError(f"Could not find api version policy file at {file_path}")
+
+        with open(file_path, "r") as f:
+            document = json.load(f)
+            return ApiVersionPolicy(
+                mode=document.get("mode", ApiVersionPolicyMode.error),
+                allow=document.get("allow"),
+                deny=document.get("deny"),
+                max_age_days=document.get("maxAgeDays"),
+            )
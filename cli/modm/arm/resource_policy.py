@@ -0,0 +1,136 @@
+import copy
+import json
+import os
+import re
+from .arm_template import ArmTemplate
+
+
+# resource types that don't support tags, matched by prefix
+UNTAGGABLE_RESOURCE_TYPES = [
+    "microsoft.authorization/",
+    "microsoft.insights/diagnosticsettings",
+    "microsoft.resources/tags",
+]
+
+
+class ResourcePolicyMode:
+    error = "error"
+    warn = "warn"
+    fix = "fix"
+
+
+class ResourcePolicy:
+    """
+    Validates the resources of an ARM template against an offer's required tags and naming conventions.
+
+    Example policy file:
+    {
+        "mode": "fix",
+        "requiredTags": { "costCenter": null, "managedBy": "modm" },
+        "naming": { "Microsoft.Storage/storageAccounts": "^st[a-z0-9]{3,22}$" }
+    }
+
+    In fix mode, required tags that have a default value are added to the resources missing them. Tags without
+    a default value and names not matching their pattern can't be fixed and fail validation.
+    Tags and names that are template expressions can't be evaluated at packaging time and are skipped.
+    Required tags only apply to resource types that support tags, e.g. not role assignments, locks or other
+    extension resources.
+    """
+
+    def __init__(self, mode: str = ResourcePolicyMode.error, required_tags: dict = None, naming: dict = None):
+        if mode not in [ResourcePolicyMode.error, ResourcePolicyMode.warn, ResourcePolicyMode.fix]:
+            raise ValueError(f"Unsupported resource policy mode {mode}")
+
+        self.mode = mode
+        self.required_tags = dict(required_tags or {})
+        self.naming = {resource_type.lower(): re.compile(pattern) for resource_type, pattern in (naming or {}).items()}
+
+    @property
+    def is_enforced(self) -> bool:
+        """Whether violations fail validation, otherwise they are reported as warnings"""
+        return self.mode != ResourcePolicyMode.warn
+
+    def validate(self, template: ArmTemplate) -> list[ValueError]:
+        results = []
+
+        for resource in self._get_resources(template.document):
+            resource_type = resource.get("type")
+            name = resource.get("name")
+            tags = resource.get("tags", {})
+
+            if self._supports_tags(resource) and isinstance(tags, dict):
+                missing = [tag for tag in self.required_tags if tag not in tags]
+
+                if len(missing) > 0:
+                    message = f"The resource {name} of type {resource_type} is missing the required tags {', '.join(missing)}."
+                    results.append(ValueError({"message": message, "properties": [f"{resource_type}/{name}"]}))
+
+            pattern = self.naming.get(resource_type.lower())
+
+            if pattern is not None and isinstance(name, str) and not name.startswith("[") and pattern.search(name) is None:
+                message = f"The name {name} of resource type {resource_type} does not match the naming convention {pattern.pattern}."
+                results.append(ValueError({"message": message, "properties": [f"{resource_type}/{name}"]}))
+
+        return results
+
+    def fix(self, template: ArmTemplate) -> ArmTemplate:
+        """Returns a copy of the template with the default values added for missing required tags"""
+        fixed = ArmTemplate(copy.deepcopy(template.document), template.name)
+        defaults = {tag: value for tag, value in self.required_tags.items() if value is not None}
+
+        if len(defaults) == 0:
+            return fixed
+
+        for resource in self._get_resources(fixed.document):
+            if not self._supports_tags(resource):
+                continue
+
+            tags = resource.setdefault("tags", {})
+
+            if isinstance(tags, dict):
+                for tag, value in defaults.items():
+                    tags.setdefault(tag, value)
+
+        return fixed
+
+    def _supports_tags(self, resource: dict) -> bool:
+        """Whether the resource takes tags. Extension resources, those declaring a scope, don't"""
+        if "scope" in resource:
+            return False
+
+        resource_type = resource.get("type").lower()
+        return not any(resource_type.startswith(untaggable) for untaggable in UNTAGGABLE_RESOURCE_TYPES)
+
+    def _get_resources(self, document: dict):
+        """
+        Returns the top level resources, including those of nested deployment templates.
+        Child resources inherit their parent's tags and many don't support tags themselves
+        """
+        resources = document.get("resources", [])
+
+        # languageVersion 2.0 templates declare resources by symbolic name
+        if isinstance(resources, dict):
+            resources = list(resources.values())
+
+        for resource in resources:
+            if not isinstance(resource.get("type"), str):
+                continue
+
+            yield resource
+
+            nested_template = resource.get("properties", {}).get("template")
+            if isinstance(nested_template, dict):
+                yield from self._get_resources(nested_template)
+
+    @staticmethod
+    def from_file(file_path):
+        if not os.path.exists(file_path):
+            raise FileNotFoundError(f"Could not find resource policy file at {file_path}")
+
+        with open(file_path, "r") as f:
+            document = json.load(f)
+            return ResourcePolicy(
+                mode=document.get("mode", ResourcePolicyMode.error),
+                required_tags=document.get("requiredTags"),
+                naming=document.get("naming"),
+            )
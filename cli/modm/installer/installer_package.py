@@ -3,6 +3,7 @@ from pathlib import Path
 import modm._zip_utils as ziputils
 import shutil
 import tempfile
+from modm.arm.arm_template import ArmTemplate
 from modm.arm.bicep_template_compiler import BicepTemplateCompiler
 
 from modm.installer.solution_template_type import SolutionTemplateType
@@ -18,8 +19,9 @@ class InstallerPackage:
 
     file_name = "installer.zip"
 
-    def __init__(self, manifest: ManifestInfo):
+    def __init__(self, manifest: ManifestInfo, main_template: ArmTemplate = None):
         self.manifest = manifest
+        self.main_template = main_template
 
     def create(self) -> InstallerPackageResult:
         validation_results = self.manifest.validate()
@@ -61,6 +63,10 @@ class InstallerPackage:
 
         self._copy_dir(src_templates_dir, new_templates_dir)
 
+        # replaces the copied main template, e.g. with a version that has policy fixes applied
+        if self.main_template is not None:
+            self.main_template.write(new_templates_dir / self.manifest.solution_template.name)
+
         if self.manifest.has_bicep_source:
             bicep_dir = new_templates_dir / ".bicep"
             self._copy_dir(self.manifest.bicep_templates_dir, bicep_dir)
@@ -73,15 +79,16 @@ class InstallerPackage:
     def _copy_dir(self, src_dir: Path, dest_dir):
         shutil.copytree(str(src_dir), str(dest_dir), dirs_exist_ok=True)
 
-def create_installer_package(manifest) -> InstallerPackageResult:
+def create_installer_package(manifest, main_template: ArmTemplate = None) -> InstallerPackageResult:
     """
     Creates an installer package for the given manifest.
 
     Args:
       manifest (ManifestInfo): instance of ManifestInfo
+      main_template (ArmTemplate, optional): the ARM template to package in place of the manifest's main template
 
     Returns:
       pathlib.Path: The the installer package file as Path object.
     """
-    installer_package = InstallerPackage(manifest)
+    installer_package = InstallerPackage(manifest, main_template)
     return installer_package.create()
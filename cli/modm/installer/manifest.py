@@ -5,6 +5,7 @@ import tempfile
 from msrest.serialization import Model
 import copy
 import os
+import uuid
 import jsonschema
 from pathlib import Path
 from modm.arm.bicep_template_compiler import BicepTemplateCompiler
@@ -24,6 +25,8 @@ class ManifestInfo(Model):
         "solution_template": {"key": "mainTemplate", "type": "str"},
         "deployment_type": {"key": "deploymentType", "type": "str"},
         "offer": {"key": "offer", "type": "OfferProperties"},
+        "partner_id": {"key": "partnerId", "type": "str"},
+        "version": {"key": "version", "type": "str"},
     }
 
     def __init__(self, solution_template: Path, **kwargs):
@@ -37,6 +40,12 @@ class ManifestInfo(Model):
 
         self.offer = OfferProperties()
 
+        # the customer usage attribution GUID of the partner, sent with the deployment requests
+        self.partner_id = kwargs.get("partner_id", None)
+
+        # the version of MODM the package is built for, sent along with the partner id
+        self.version = kwargs.get("version", None)
+
         if self._template_type == SolutionTemplateType.terraform:
             self.deployment_type = DeploymentType.terraform
         else:
@@ -91,6 +100,12 @@ class ManifestInfo(Model):
         if self.deployment_type == DeploymentType.terraform and main_template_file.suffix != ".tf":
             validation_results.append(ValueError(f"Main template file {self.solution_template} must have a .tf extension"))
 
+        if self.partner_id is not None:
+            try:
+                uuid.UUID(self.partner_id)
+            except ValueError:
+                validation_results.append(ValueError(f"Partner id {self.partner_id} must be a GUID"))
+
         return validation_results
 
     def _compile_bicep_template(self):
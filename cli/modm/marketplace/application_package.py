@@ -2,6 +2,7 @@ from pathlib import Path
 from zipfile import ZipFile
 from modm.marketplace.application_package_resources import ApplicationPackageResources
 from modm.release.release_info import ReferenceInfo
+from modm.release.version import Version
 from modm.release.release_provider import ReleaseProvider
 from .application_package_result import ApplicationPackageResult
 from .application_packaging_options import ApplicationPackageOptions
@@ -58,15 +59,19 @@ class ApplicationPackage:
         validation_results = self.info.validate()
 
         if len(validation_results) > 0:
-            return ApplicationPackageResult(validation_results=validation_results)
+            return ApplicationPackageResult(validation_results=validation_results, warnings=self.info.warnings)
 
-        installer_package = create_installer_package(self.info.manifest)
+        version = getattr(options, "version", None)
+        if version is not None:
+            self.info.manifest.version = Version(version).name.removeprefix("v")
+
+        installer_package = create_installer_package(self.info.manifest, self.info.fixed_solution_template)
 
         self._finalize_main_template(installer_package, options)
         self._finalize_view_definition(options)
         self._finalize_create_ui_definition(options)
 
-        result = ApplicationPackageResult(installer_package=installer_package)
+        result = ApplicationPackageResult(installer_package=installer_package, warnings=self.info.warnings)
         result.file = self._zip(installer_package, options)
 
         if result.file is None or not result.file.exists():
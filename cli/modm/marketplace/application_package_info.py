@@ -1,4 +1,8 @@
 import json
+from modm.arm.api_version_policy import ApiVersionPolicy
+from modm.arm.arm_template import ArmTemplate
+from modm.arm.resource_policy import ResourcePolicy, ResourcePolicyMode
+from modm.installer.solution_template_type import SolutionTemplateType
 from .create_ui_definition import CreateUiDefinition
 from modm.installer import ManifestInfo
 from msrest.serialization import Model
@@ -6,7 +10,16 @@ from pathlib import Path
 
 
 class ApplicationPackageInfo(Model):
-    def __init__(self, solution_template: str | Path, create_ui_definition: str | CreateUiDefinition, name="", description=""):
+    def __init__(
+        self,
+        solution_template: str | Path,
+        create_ui_definition: str | CreateUiDefinition,
+        name="",
+        description="",
+        api_version_policy: ApiVersionPolicy = None,
+        partner_id: str = None,
+        resource_policy: ResourcePolicy = None,
+    ):
         """
         Initializes a new instance of the ApplicationPackage class.
 
@@ -15,6 +28,9 @@ class ApplicationPackageInfo(Model):
             create_ui_definition (str | CreateUiDefinition): The path to the create UI definition file, or a CreateUiDefinition object.
             name (str, optional): The name of the offer. Defaults to "".
             description (str, optional): The description of the offer. Defaults to "".
+            api_version_policy (ApiVersionPolicy, optional): The policy the apiVersions of an ARM solution template are validated with.
+            partner_id (str, optional): The partner's customer usage attribution GUID.
+            resource_policy (ResourcePolicy, optional): The offer's required tags and naming conventions the resources of an ARM solution template are validated with.
         """
         super().__init__()
         self.create_ui_definition = create_ui_definition
@@ -31,6 +47,14 @@ class ApplicationPackageInfo(Model):
         self.manifest = ManifestInfo(solution_template=solution_template)
         self.manifest.offer.name = name
         self.manifest.offer.description = description
+        self.manifest.partner_id = partner_id
+
+        self.api_version_policy = api_version_policy
+        self.resource_policy = resource_policy
+        self.warnings = []
+
+        # the solution template with the fixes of the resource policy applied, if any
+        self.fixed_solution_template = None
 
     @property
     def name(self):
@@ -58,5 +82,37 @@ class ApplicationPackageInfo(Model):
         validation_results = self.manifest.validate()
         validation_results += self.create_ui_definition.validate(template_parameters)
 
+        # bicep templates are compiled by the manifest, so they're validated as the ARM template they compile to.
+        # terraform templates don't declare apiVersions or ARM resources
+        is_arm_template = self.template_type == SolutionTemplateType.arm
+
+        if self.api_version_policy is not None and not is_arm_template:
+            self.warnings.append(ValueError("The api version policy only applies to ARM and Bicep solution templates and was not evaluated."))
+
+        if self.resource_policy is not None and not is_arm_template:
+            self.warnings.append(ValueError("The resource policy only applies to ARM and Bicep solution templates and was not evaluated."))
+
+        if self.api_version_policy is not None and is_arm_template:
+            policy_results = self.api_version_policy.validate(ArmTemplate.from_file(self.manifest.solution_template))
+
+            if self.api_version_policy.is_enforced:
+                validation_results += policy_results
+            else:
+                self.warnings += policy_results
+
+        if self.resource_policy is not None and is_arm_template:
+            template = ArmTemplate.from_file(self.manifest.solution_template)
+
+            if self.resource_policy.mode == ResourcePolicyMode.fix:
+                template = self.resource_policy.fix(template)
+                self.fixed_solution_template = template
+
+            policy_results = self.resource_policy.validate(template)
+
+            if self.resource_policy.is_enforced:
+                validation_results += policy_results
+            else:
+                self.warnings += policy_results
+
         return validation_results
     
\ No newline at end of file
@@ -5,11 +5,13 @@ class ApplicationPackageResult(Model):
     _attribute_map = {
         "file": {"key": "file", "type": "str"},
         "validation_results": {"key": "validationResults", "type": "[object]"},
+        "warnings": {"key": "warnings", "type": "[object]"},
     }
 
     def __init__(self, **kwargs) -> None:
         self.file = None
         self.validation_results = kwargs.get("validation_results", [])
+        self.warnings = kwargs.get("warnings", [])
         self._installer_package = kwargs.get("installer_package", None)
 
     @property
@@ -1,6 +1,7 @@
 from copy import deepcopy
 import json
 import os
+import re
 from modm.installer.reserved_template_parameter import ReservedTemplateParameter
 from ..arm.arm_template_parameter import ArmTemplateParameter
 
@@ -50,6 +51,48 @@ class CreateUiDefinition:
 
         return validation_results
 
+    def get_output_elements(self) -> dict:
+        """
+        Returns the UI element each output is mapped from, keyed by the output name.
+        Outputs that are not mapped from an element, e.g. [location()], have a value of None
+        """
+        outputs = self.document["parameters"].get("outputs") or {}
+        elements = {}
+
+        for name, expression in outputs.items():
+            elements[name] = self._find_element(expression)
+
+        return elements
+
+    def _find_element(self, expression):
+        if not isinstance(expression, str):
+            return None
+
+        match = re.match(r"^\[(?:steps\('(?P<step>[^']+)'\)|basics\(\))\.(?P<path>[\w\.]+)", expression) or \
+            re.match(r"^\[basics\('(?P<path>[^']+)'\)\]", expression)
+
+        if match is None:
+            return None
+
+        step = match.groupdict().get("step")
+
+        if step is None:
+            elements = self.document["parameters"].get("basics") or []
+        else:
+            steps = self.document["parameters"].get("steps") or []
+            elements = next((s.get("elements", []) for s in steps if s.get("name") == step), [])
+
+        # sections nest their elements, e.g. steps('setup').section.textBox
+        element = None
+        for name in match.group("path").split("."):
+            found = next((e for e in elements if e.get("name") == name), None)
+            if found is None:
+                break
+            element = found
+            elements = found.get("elements", [])
+
+        return element
+
     def to_json(self):
         return json.dumps(self.document, indent=4)
 
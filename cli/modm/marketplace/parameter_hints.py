@@ -0,0 +1,78 @@
+import json
+from modm.arm.arm_template import ArmTemplate
+from modm.installer.reserved_template_parameter import is_reserved
+from modm.installer.solution_template_type import SolutionTemplateType
+from .application_package_info import ApplicationPackageInfo
+
+# the ARM template parameter properties that constrain the value
+TEMPLATE_CONSTRAINTS = ["allowedValues", "minValue", "maxValue", "minLength", "maxLength"]
+
+# the createUiDefinition element constraints that apply to the value
+UI_CONSTRAINTS = ["required", "regex", "validationMessage", "allowedValues"]
+
+
+class ParameterHints:
+    """
+    A machine-readable description of the parameters of the app's main template, combining the
+    template's own declarations with the createUiDefinition.json element each parameter is mapped from,
+    so that a UI can render an input form for the template
+    """
+
+    def __init__(self, info: ApplicationPackageInfo):
+        self.info = info
+
+    def get(self) -> list[dict]:
+        declarations = self._get_template_declarations()
+        elements = self.info.create_ui_definition.get_output_elements()
+        outputs = self.info.create_ui_definition.document["parameters"].get("outputs") or {}
+        hints = []
+
+        for parameter in self.info.template_parameters:
+            # reserved parameters are provided by the installer, not the user
+            if is_reserved(parameter.name):
+                continue
+
+            hint = parameter.value()
+            hint["name"] = parameter.name
+
+            declaration = declarations.get(parameter.name, {})
+            description = declaration.get("metadata", {}).get("description")
+
+            if description is not None:
+                hint["description"] = description
+
+            if "defaultValue" in declaration:
+                hint["defaultValue"] = declaration["defaultValue"]
+
+            constraints = {key: declaration[key] for key in TEMPLATE_CONSTRAINTS if key in declaration}
+
+            if parameter.name in outputs:
+                ui = {"output": outputs[parameter.name]}
+                element = elements.get(parameter.name)
+
+                if element is not None:
+                    ui["element"] = element.get("name")
+                    ui["type"] = element.get("type")
+                    ui["label"] = element.get("label")
+                    ui["toolTip"] = element.get("toolTip")
+
+                    element_constraints = element.get("constraints") or {}
+                    constraints.update({key: element_constraints[key] for key in UI_CONSTRAINTS if key in element_constraints})
+
+                hint["ui"] = {key: value for key, value in ui.items() if value is not None}
+
+            hint["constraints"] = constraints
+            hints.append(hint)
+
+        return hints
+
+    def to_json(self):
+        return json.dumps({"templateType": self.info.template_type.value, "parameters": self.get()}, indent=2)
+
+    def _get_template_declarations(self) -> dict:
+        """Terraform variables are only described by name and type, ARM parameters can declare constraints"""
+        if self.info.template_type != SolutionTemplateType.arm:
+            return {}
+
+        template = ArmTemplate.from_file(self.info.manifest.solution_template)
+        return template.document.get("parameters", {})
@@ -45,6 +45,18 @@ class TestManifest(unittest.TestCase):
         manifest = ManifestInfo(solution_template="main.tf", deployment_type=DeploymentType.terraform)
 
 
+    def test_manifest_info_partner_id_serialization(self):
+        manifest = ManifestInfo(solution_template="main.tf", partner_id="8d5fd7f1-0224-4b4e-a5ab-5e0c8f5b1a2c")
+
+        json = manifest.serialize()
+        self.assertEqual(json["partnerId"], "8d5fd7f1-0224-4b4e-a5ab-5e0c8f5b1a2c")
+
+        self.assertNotIn("partnerId", ManifestInfo(solution_template="main.tf").serialize())
+
+    def test_manifest_info_version_serialization(self):
+        manifest = ManifestInfo(solution_template="main.tf", version="1.2.3")
+        self.assertEqual(manifest.serialize()["version"], "1.2.3")
+
     def test_offer_info(self):
         offer = OfferProperties(name = "test", description = "test")
         self.assertEqual(offer.name, "test")
@@ -0,0 +1,61 @@
+from datetime import date
+from modm.arm.api_version_policy import ApiVersionPolicy, ApiVersionPolicyMode
+from modm.arm.arm_template import ArmTemplate
+from tests import TestCaseBase
+
+
+class TestApiVersionPolicy(TestCaseBase):
+    def setUp(self):
+        self.template = ArmTemplate(
+            {
+                "parameters": {},
+                "resources": [
+                    {"type": "Microsoft.Storage/storageAccounts", "apiVersion": "2023-01-01"},
+                    {
+                        "type": "Microsoft.Web/sites",
+                        "apiVersion": "2016-08-01",
+                        "resources": [{"type": "config", "apiVersion": "2022-09-01"}],
+                    },
+                    {
+                        "type": "Microsoft.Resources/deployments",
+                        "apiVersion": "2022-09-01",
+                        "properties": {"template": {"resources": [{"type": "Microsoft.Network/virtualNetworks", "apiVersion": "2019-02-01-preview"}]}},
+                    },
+                ],
+            }
+        )
+
+    def test_deny(self):
+        policy = ApiVersionPolicy(deny={"microsoft.web/sites": ["2016-08-01"]})
+
+        results = policy.validate(self.template)
+        self.assertEqual(len(results), 1)
+        self.assertIn("denied", str(results[0]))
+
+    def test_child_resources_match_by_full_type(self):
+        policy = ApiVersionPolicy(deny={"Microsoft.Web/sites/config": ["2022-09-01"]})
+
+        results = policy.validate(self.template)
+        self.assertEqual(len(results), 1)
+        self.assertIn("Microsoft.Web/sites/config@2022-09-01", str(results[0]))
+
+    def test_allow(self):
+        policy = ApiVersionPolicy(allow={"Microsoft.Storage/storageAccounts": ["2022-09-01"]})
+
+        results = policy.validate(self.template)
+        self.assertEqual(len(results), 1)
+        self.assertIn("Microsoft.Storage/storageAccounts@2023-01-01", str(results[0]))
+
+    def test_max_age_includes_nested_resources(self):
+        policy = ApiVersionPolicy(max_age_days=365 * 3, today=date(2024, 1, 1))
+
+        results = policy.validate(self.template)
+        self.assertEqual(len(results), 2)
+
+    def test_warn_mode_is_not_enforced(self):
+        self.assertTrue(ApiVersionPolicy().is_enforced)
+        self.assertFalse(ApiVersionPolicy(mode=ApiVersionPolicyMode.warn).is_enforced)
+
+    def test_invalid_mode(self):
+        with self.assertRaises(ValueError):
+            ApiVersionPolicy(mode="ignore")
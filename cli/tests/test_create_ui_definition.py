@@ -52,3 +52,22 @@ class TestCreateUiDefinition(TestCaseBase):
         result = self.create_ui_definition.validate(template_input_parameters)
         self.assertEqual((len(result)), 1)
     
+
+    def test_get_output_elements(self):
+        elements = self.create_ui_definition.get_output_elements()
+
+        self.assertIsNone(elements["location"])
+        self.assertIsNone(elements["imageReference"])
+        self.assertEqual(elements["adminUsername"]["name"], "tbAdminUser")
+        self.assertEqual(elements["adminPassword"]["type"], "Microsoft.Common.PasswordBox")
+
+    def test_get_output_elements_in_section(self):
+        self.create_ui_definition.document = {
+            "parameters": {
+                "steps": [{"name": "setup", "elements": [{"name": "section", "elements": [{"name": "tbName", "type": "Microsoft.Common.TextBox"}]}]}],
+                "outputs": {"name": "[steps('setup').section.tbName]"},
+            }
+        }
+
+        elements = self.create_ui_definition.get_output_elements()
+        self.assertEqual(elements["name"]["name"], "tbName")
@@ -0,0 +1,27 @@
+import json
+from modm.marketplace.application_package_info import ApplicationPackageInfo
+from modm.marketplace.parameter_hints import ParameterHints
+from tests import TestCaseBase
+
+
+class TestParameterHints(TestCaseBase):
+    def setUp(self):
+        self.main_template_file = self.data_path / "app_packaging" / "templates" / "main.tf"
+        self.create_ui_definition_file = self.data_path / "app_packaging" / "createUiDefinition.json"
+
+        info = ApplicationPackageInfo(self.main_template_file, self.create_ui_definition_file)
+        self.parameter_hints = ParameterHints(info)
+
+    def test_get(self):
+        hints = {hint["name"]: hint for hint in self.parameter_hints.get()}
+
+        self.assertEqual(len(hints), 3)
+        self.assertEqual(hints["location"]["type"], "string")
+        self.assertEqual(hints["secure_variable"]["type"], "secureString")
+        self.assertEqual(hints["location"]["ui"]["output"], "[location()]")
+
+    def test_to_json(self):
+        document = json.loads(self.parameter_hints.to_json())
+
+        self.assertEqual(document["templateType"], "terraform")
+        self.assertEqual(len(document["parameters"]), 3)
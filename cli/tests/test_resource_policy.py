@@ -0,0 +1,78 @@
+from modm.arm.arm_template import ArmTemplate
+from modm.arm.resource_policy import ResourcePolicy, ResourcePolicyMode
+from tests import TestCaseBase
+
+
+class TestResourcePolicy(TestCaseBase):
+    def setUp(self):
+        self.template = ArmTemplate(
+            {
+                "parameters": {},
+                "resources": [
+                    {"type": "Microsoft.Storage/storageAccounts", "name": "contosostorage", "tags": {"costCenter": "1234"}},
+                    {"type": "Microsoft.Web/sites", "name": "[parameters('siteName')]", "tags": "[parameters('tags')]"},
+                    {
+                        "type": "Microsoft.Resources/deployments",
+                        "name": "nested",
+                        "tags": {"costCenter": "1234"},
+                        "properties": {"template": {"resources": [{"type": "Microsoft.Network/virtualNetworks", "name": "vnet"}]}},
+                    },
+                ],
+            }
+        )
+
+    def test_required_tags(self):
+        policy = ResourcePolicy(required_tags={"costCenter": None})
+        results = policy.validate(self.template)
+
+        # tags that are expressions can't be evaluated
+        self.assertEqual(1, len(results))
+        self.assertIn("Microsoft.Network/virtualNetworks/vnet", results[0].args[0]["properties"])
+
+    def test_naming(self):
+        policy = ResourcePolicy(naming={"microsoft.storage/storageAccounts": "^st[a-z0-9]{3,22}$", "Microsoft.Web/sites": "^app-"})
+        results = policy.validate(self.template)
+
+        self.assertEqual(1, len(results))
+        self.assertIn("contosostorage", results[0].args[0]["message"])
+
+    def test_fix_adds_default_tags(self):
+        policy = ResourcePolicy(mode=ResourcePolicyMode.fix, required_tags={"costCenter": None, "managedBy": "modm"})
+        fixed = policy.fix(self.template)
+        results = policy.validate(fixed)
+
+        # only the costCenter tag of the vnet has no default to fix it with
+        self.assertEqual(1, len(results))
+        self.assertIn("costCenter", results[0].args[0]["message"])
+        self.assertEqual("modm", fixed.document["resources"][0]["tags"]["managedBy"])
+        self.assertNotIn("managedBy", self.template.document["resources"][0]["tags"])
+
+    def test_required_tags_skip_resources_without_tags(self):
+        template = ArmTemplate(
+            {
+                "parameters": {},
+                "resources": [
+                    {"type": "Microsoft.Authorization/roleAssignments", "name": "[guid(resourceGroup().id)]"},
+                    {"type": "Microsoft.Security/advancedThreatProtectionSettings", "name": "current", "scope": "[format('Microsoft.Storage/storageAccounts/{0}', 'contosostorage')]"},
+                ],
+            }
+        )
+
+        policy = ResourcePolicy(mode=ResourcePolicyMode.fix, required_tags={"costCenter": None, "managedBy": "modm"})
+        fixed = policy.fix(template)
+
+        self.assertEqual(0, len(policy.validate(template)))
+        self.assertNotIn("tags", fixed.document["resources"][0])
+        self.assertNotIn("tags", fixed.document["resources"][1])
+
+    def test_fix_without_defaults_adds_no_tags(self):
+        policy = ResourcePolicy(mode=ResourcePolicyMode.fix, required_tags={"costCenter": None})
+        fixed = policy.fix(self.template)
+
+        nested_resources = fixed.document["resources"][2]["properties"]["template"]["resources"]
+        self.assertNotIn("tags", nested_resources[0])
+
+    def test_mode(self):
+        self.assertTrue(ResourcePolicy(mode=ResourcePolicyMode.fix).is_enforced)
+        self.assertFalse(ResourcePolicy(mode=ResourcePolicyMode.warn).is_enforced)
+        self.assertRaises(ValueError, ResourcePolicy, mode="ignore")
@@ -15,6 +15,22 @@ echo "-----------------"
 parameters_file="parameters.json"
 template_file=$(cat ./manifest.json | jq -r '.mainTemplate')
 
+# customer usage attribution, see https://learn.microsoft.com/en-us/partner-center/marketplace/azure-partner-customer-usage-attribution
+partner_id=$(cat ./manifest.json | jq -r '.partnerId // empty')
+modm_version=$(cat ./manifest.json | jq -r '.version // empty')
+
+if [ -n "$partner_id" ]; then
+  user_agent="pid-$partner_id"
+fi
+
+if [ -n "$modm_version" ]; then
+  user_agent="${user_agent:+$user_agent }modm/$modm_version"
+fi
+
+if [ -n "$user_agent" ]; then
+  export AZURE_HTTP_USER_AGENT="$user_agent"
+fi
+
 resource_group_name=$(cat ./$parameters_file | jq -r '.parameters.resourceGroupName.value')
 
 # Check if resource_group_name is empty
@@ -27,4 +43,9 @@ fi
 az deployment group create --resource-group $resource_group_name \
     --name deployment1 \
     --template-file $template_file \
-    --parameters @$parameters_file
\ No newline at end of file
+    --parameters @$parameters_file || exit $?
+
+# capture the outputs for the engine to read once the build completes
+az deployment group show --resource-group $resource_group_name \
+    --name deployment1 \
+    --query properties.outputs > ./outputs.json
\ No newline at end of file
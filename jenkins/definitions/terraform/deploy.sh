@@ -20,8 +20,24 @@ fi
 # from the job output
 echo "-----------------"
 
+# customer usage attribution, see https://learn.microsoft.com/en-us/partner-center/marketplace/azure-partner-customer-usage-attribution
+partner_id=$(cat ./manifest.json | jq -r '.partnerId // empty')
+modm_version=$(cat ./manifest.json | jq -r '.version // empty')
+
+if [ -n "$partner_id" ]; then
+  export ARM_PARTNER_ID=$partner_id
+fi
+
+# the azurerm provider appends AZURE_HTTP_USER_AGENT to its user agent
+if [ -n "$modm_version" ]; then
+  export AZURE_HTTP_USER_AGENT="modm/$modm_version"
+fi
+
 # Initialize Terraform (required before first run)
 terraform init -backend=false
 
 # Apply the parent Terraform template
-terraform apply -auto-approve
+terraform apply -auto-approve || exit $?
+
+# capture the outputs for the engine to read once the build completes, stripping the values of sensitive outputs
+terraform output -json | jq 'map_values(if .sensitive then .value = null else . end)' > ./outputs.json
@@ -39,6 +39,20 @@ namespace ClientApp.Cleanup
             await Execute(deleteOperations);
         }
 
+        public async Task<List<string>> ListResourcesToDeleteAsync(string resourceGroupName, CancellationToken cancellationToken = default)
+        {
+            var options = new DeleteResourceOptions
+            {
+                Resources = await this.GetResourcesToDeleteAsync(resourceGroupName, cancellationToken)
+            };
+
+            return ResourceTypes.Keys
+                .Select(options.GetResource)
+                .Where(resource => resource is not null)
+                .Select(resource => resource.Id.ToString())
+                .ToList();
+        }
+
         private async Task Execute(ImmutableList<Func<Task<DeleteResourceResult>>> operations)
         {
             foreach (var operation in operations)
@@ -62,7 +76,7 @@ namespace ClientApp.Cleanup
             var options = new DeleteResourceOptions
             {
                 ResourceGroup = await this.GetResourceGroupResourceAsync(resourceGroupName),
-                Resources = await this.GetResourcesToDeleteAsync(resourceGroupName)
+                Resources = await this.GetResourcesToDeleteAsync(resourceGroupName, cancellationToken)
             };
 
             var deleteOperations = ResourceTypes
@@ -71,15 +85,15 @@ namespace ClientApp.Cleanup
             return deleteOperations;
         }
 
-        protected virtual async Task<List<GenericResource>> GetResourcesToDeleteAsync(string resourceGroupName)
+        protected virtual async Task<List<GenericResource>> GetResourcesToDeleteAsync(string resourceGroupName, CancellationToken cancellationToken = default)
         {
-            var subscription = await this.client.GetDefaultSubscriptionAsync();
-            var response = await subscription.GetResourceGroupAsync(resourceGroupName);
+            var subscription = await this.client.GetDefaultSubscriptionAsync(cancellationToken);
+            var response = await subscription.GetResourceGroupAsync(resourceGroupName, cancellationToken);
             var resourceGroup = response.Value;
 
             var resourcesToDelete = new List<GenericResource>();
 
-            await foreach (var resource in resourceGroup.GetGenericResourcesAsync())
+            await foreach (var resource in resourceGroup.GetGenericResourcesAsync(cancellationToken: cancellationToken))
             {
                 if (resource.Data.Tags != null && resource.Data.Tags.TryGetValue(TagName, out var tagValue) && tagValue == "true")
                 {
@@ -123,9 +137,14 @@ namespace ClientApp.Cleanup
             public ResourceGroupResource ResourceGroup { get; init; }
             public List<GenericResource> Resources { get; init; }
 
+            public GenericResource GetResource(ResourceType resourceType)
+            {
+                return Resources.FirstOrDefault(r => r.Id.ResourceType == resourceType);
+            }
+
             public IDeleteResourceRequest CreateCommand(ResourceType resourceType, Type commandType)
             {
-                var resource = Resources.FirstOrDefault(r => r.Id.ResourceType == resourceType);
+                var resource = GetResource(resourceType);
 
                 if (resource is null)
                 {
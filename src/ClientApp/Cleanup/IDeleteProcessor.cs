@@ -3,5 +3,10 @@
     public interface IDeleteProcessor
 	{
 		Task DeleteResourcesAsync(string resourceGroup, CancellationToken cancellationToken = default);
+
+		/// <summary>
+		/// Dry run of <see cref="DeleteResourcesAsync"/>, listing the resources that would be deleted in the order they would be deleted
+		/// </summary>
+		Task<List<string>> ListResourcesToDeleteAsync(string resourceGroup, CancellationToken cancellationToken = default);
 	}
 }
\ No newline at end of file
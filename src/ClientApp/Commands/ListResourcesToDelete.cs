@@ -0,0 +1,22 @@
+﻿using MediatR;
+
+namespace ClientApp.Commands
+{
+    /// <summary>
+    /// Lists the tagged installer resources that <see cref="InitiateDelete"/> would delete, without deleting them
+    /// </summary>
+    public class ListResourcesToDelete : IRequest<List<string>>
+    {
+        private readonly string resourceGroupName;
+
+        public ListResourcesToDelete(string resourceGroupName)
+        {
+            this.resourceGroupName = resourceGroupName;
+        }
+
+        public string ResourceGroupName
+        {
+            get { return this.resourceGroupName; }
+        }
+    }
+}
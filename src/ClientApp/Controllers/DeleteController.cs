@@ -21,6 +21,25 @@ namespace ClientApp.Controllers
             this.logger = logger;
         }
 
+        /// <summary>
+        /// Dry run of the delete, listing the resources that would be deleted
+        /// </summary>
+        [HttpGet]
+        [Route("resources/{resourceGroupName}/deletemodmresources")]
+        public async Task<IActionResult> GetResourcesWithTagAsync([FromRoute] string resourceGroupName, CancellationToken cancellationToken)
+        {
+            try
+            {
+                var resources = await this.mediator.Send(new ListResourcesToDelete(resourceGroupName), cancellationToken);
+                return Ok(new { Resources = resources });
+            }
+            catch (Exception ex)
+            {
+                this.logger.LogError(ex, "Error listing resources to delete");
+                return StatusCode(StatusCodes.Status500InternalServerError, new { Message = "Listing resources to delete failed." });
+            }
+        }
+
         [HttpPost]
         [Route("resources/{resourceGroupName}/deletemodmresources")]
         public async Task<IActionResult> DeleteResourcesWithTagAsync([FromRoute] string resourceGroupName)
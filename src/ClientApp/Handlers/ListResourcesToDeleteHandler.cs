@@ -0,0 +1,23 @@
+﻿using ClientApp.Commands;
+using MediatR;
+
+namespace ClientApp.Cleanup
+{
+    public class ListResourcesToDeleteHandler : IRequestHandler<ListResourcesToDelete, List<string>>
+    {
+        private readonly IDeleteProcessor deleteProcessor;
+        private readonly ILogger<ListResourcesToDeleteHandler> logger;
+
+        public ListResourcesToDeleteHandler(IDeleteProcessor deleteProcessor, ILogger<ListResourcesToDeleteHandler> logger)
+        {
+            this.deleteProcessor = deleteProcessor;
+            this.logger = logger;
+        }
+
+        public Task<List<string>> Handle(ListResourcesToDelete request, CancellationToken cancellationToken)
+        {
+            this.logger.LogInformation($"Handling ListResourcesToDelete with resource group name {request.ResourceGroupName}");
+            return this.deleteProcessor.ListResourcesToDeleteAsync(request.ResourceGroupName, cancellationToken);
+        }
+    }
+}
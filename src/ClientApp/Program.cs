@@ -17,6 +17,7 @@ using ClientApp.Cleanup;
 
 var builder = WebApplication.CreateBuilder(args);
 
+builder.Services.AddDefaultHttpClient();
 builder.Services.AddSingleton<AuthManager>();
 builder.Services.AddSingleton<AdminCredentialsProvider>();
 
@@ -38,6 +39,7 @@ builder.Services.AddHttpClient<Modm.Deployments.DeploymentClient>().ConfigureHtt
 builder.Services.AddSingleton<ProxyClientFactory>();
 
 builder.Services.AddJwtBearerAuthentication(builder.Configuration);
+builder.Services.AddIpAllowlist(builder.Configuration);
 
 builder.Services.AddControllersWithViews();
 builder.Services.AddCors(options =>
@@ -61,7 +63,7 @@ builder.Services.Configure<HostOptions>(hostOptions =>
 builder.Services.AddAzureClients(clientBuilder =>
 {
     clientBuilder.AddArmClient(builder.Configuration.GetSection("Azure"));
-    clientBuilder.UseCredential(new DefaultAzureCredential());
+    clientBuilder.UseDefaultAzureCredential(builder.Configuration);
 });
 
 builder.Services.AddSingleton<IAzureResourceManagerClient, AzureResourceManagerClient>();
@@ -90,6 +92,7 @@ else
     IdentityModelEventSource.ShowPII = true;
 }
 
+app.UseIpAllowlist();
 app.UseHttpsRedirection();
 app.UseStaticFiles();
 
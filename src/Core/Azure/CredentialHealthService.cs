@@ -0,0 +1,66 @@
+﻿using Azure.ResourceManager;
+using Microsoft.Extensions.Hosting;
+using Microsoft.Extensions.Logging;
+
+namespace Modm.Azure
+{
+    /// <summary>
+    /// Verifies at startup, and periodically after, that the configured credential can acquire a token for
+    /// Azure Resource Manager so misconfiguration or expiry shows up in the engine health before a deployment fails
+    /// </summary>
+    /// <remarks>
+    /// A successful check also leaves the acquired token cached by the credential for the first deployment
+    /// </remarks>
+	public class CredentialHealthService : BackgroundService
+	{
+        const int DefaultWaitDelaySeconds = 300;
+
+        private readonly ArmClient client;
+        private readonly ILogger<CredentialHealthService> logger;
+        private CredentialStatus status;
+
+        public CredentialHealthService(ArmClient client, ILogger<CredentialHealthService> logger)
+		{
+            this.client = client;
+            this.logger = logger;
+            this.status = CredentialStatus.Default();
+		}
+
+        protected override async Task ExecuteAsync(CancellationToken stoppingToken)
+        {
+            while (!stoppingToken.IsCancellationRequested)
+            {
+                status = await CheckAsync(stoppingToken);
+                await Task.Delay(DefaultWaitDelaySeconds * 1000, stoppingToken);
+            }
+        }
+
+        public CredentialStatus GetStatus()
+        {
+            return status;
+        }
+
+        internal async Task<CredentialStatus> CheckAsync(CancellationToken cancellationToken)
+        {
+            var result = new CredentialStatus { LastChecked = DateTimeOffset.UtcNow };
+
+            try
+            {
+                // resolving the subscription requires a token for the management endpoint
+                var subscription = await client.GetDefaultSubscriptionAsync(cancellationToken);
+
+                result.IsHealthy = true;
+                result.Message = $"Acquired a token for subscription {subscription.Id?.SubscriptionId}.";
+            }
+            catch (Exception ex) when (ex is not OperationCanceledException)
+            {
+                logger.LogError(ex, "The credential failed to acquire a token for Azure Resource Manager");
+
+                result.IsHealthy = false;
+                result.Message = $"The credential failed to acquire a token for Azure Resource Manager. {ex.Message}";
+            }
+
+            return result;
+        }
+	}
+}
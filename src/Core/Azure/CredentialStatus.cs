@@ -0,0 +1,24 @@
+﻿using System.Text.Json.Serialization;
+
+namespace Modm.Azure
+{
+    /// <summary>
+    /// The result of the last check of the credential used to call Azure Resource Manager
+    /// </summary>
+	public record CredentialStatus
+	{
+        [JsonPropertyName("isHealthy")]
+        public bool IsHealthy { get; set; }
+
+        [JsonPropertyName("message")]
+        public string Message { get; set; }
+
+        [JsonPropertyName("lastChecked")]
+        public DateTimeOffset? LastChecked { get; set; }
+
+        public static CredentialStatus Default()
+        {
+            return new CredentialStatus { IsHealthy = false, Message = "The credential has not been checked yet." };
+        }
+	}
+}
@@ -48,8 +48,8 @@ namespace Modm.Azure
             {
                 ClientId = token.ClientId,
                 SubscriptionId = metadata.Compute.SubscriptionId,
-                TenantId = Guid.Parse(accessToken.Claims.First(c => c.Type == "oid").Value),
-                ObjectId = Guid.Parse(accessToken.Claims.First(c => c.Type == "tid").Value)
+                TenantId = Guid.Parse(accessToken.Claims.First(c => c.Type == "tid").Value),
+                ObjectId = Guid.Parse(accessToken.Claims.First(c => c.Type == "oid").Value)
             };
         }
 
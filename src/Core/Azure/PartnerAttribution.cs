@@ -0,0 +1,74 @@
+﻿using System.Reflection;
+using Microsoft.Extensions.Hosting;
+using Microsoft.Extensions.Logging;
+using Modm.Deployments;
+
+namespace Modm.Azure
+{
+    /// <summary>
+    /// The customer usage attribution of the current deployment, sent in the user agent of the engine's own ARM requests
+    /// the same way the deployment jobs send it, i.e. pid-{partnerId} modm/{version}
+    /// </summary>
+    /// <remarks>
+    /// Set when a deployment's definition is written to the deployment file, and restored from it at startup
+    /// </remarks>
+	public class PartnerAttribution : IHostedService
+	{
+        /// <summary>
+        /// The version of the running engine
+        /// </summary>
+        public static readonly string Version = GetVersion();
+
+        private readonly DeploymentFile deploymentFile;
+        private readonly ILogger<PartnerAttribution> logger;
+        private volatile string userAgent = $"modm/{Version}";
+
+        public PartnerAttribution(DeploymentFile deploymentFile, ILogger<PartnerAttribution> logger)
+		{
+            this.deploymentFile = deploymentFile;
+            this.logger = logger;
+		}
+
+        /// <summary>
+        /// The user agent to append
+        /// </summary>
+        public string UserAgent => userAgent;
+
+        /// <summary>
+        /// Sets the attribution of the deployment
+        /// </summary>
+        /// <param name="partnerId">The partner id of the deployment's manifest, null if it has none</param>
+        public void Set(string partnerId)
+        {
+            userAgent = string.IsNullOrEmpty(partnerId) ? $"modm/{Version}" : $"pid-{partnerId} modm/{Version}";
+        }
+
+        public async Task StartAsync(CancellationToken cancellationToken)
+        {
+            try
+            {
+                var deployment = await deploymentFile.ReadAsync(cancellationToken);
+                Set(deployment?.Definition?.PartnerId);
+            }
+            catch (Exception ex) when (ex is not OperationCanceledException)
+            {
+                logger.LogWarning(ex, "Failed to restore the partner attribution from the deployment file");
+            }
+        }
+
+        public Task StopAsync(CancellationToken cancellationToken)
+        {
+            return Task.CompletedTask;
+        }
+
+        private static string GetVersion()
+        {
+            var assembly = typeof(PartnerAttribution).Assembly;
+            var version = assembly.GetCustomAttribute<AssemblyInformationalVersionAttribute>()?.InformationalVersion
+                ?? assembly.GetName().Version?.ToString();
+
+            // the informational version may carry the source revision, e.g. 1.0.0+abc123
+            return version?.Split('+')[0] ?? "unknown";
+        }
+    }
+}
@@ -0,0 +1,38 @@
+﻿using Azure.Core;
+using Azure.Core.Pipeline;
+
+namespace Modm.Azure
+{
+    /// <summary>
+    /// Appends the <see cref="PartnerAttribution"/> to the user agent of requests made by azure clients
+    /// </summary>
+    /// <remarks>
+    /// DiagnosticsOptions.ApplicationId is limited to 24 characters, which a partner id doesn't fit in
+    /// </remarks>
+	public class UserAgentPolicy : HttpPipelineSynchronousPolicy
+	{
+        const string UserAgentHeaderName = "User-Agent";
+
+        private readonly PartnerAttribution attribution;
+
+        public UserAgentPolicy(PartnerAttribution attribution)
+		{
+            this.attribution = attribution;
+		}
+
+        public override void OnSendingRequest(HttpMessage message)
+        {
+            var value = attribution.UserAgent;
+
+            if (!message.Request.Headers.TryGetValue(UserAgentHeaderName, out var userAgent) || string.IsNullOrEmpty(userAgent))
+            {
+                message.Request.Headers.SetValue(UserAgentHeaderName, value);
+            }
+            // retries send the same request again
+            else if (!userAgent.Contains(value))
+            {
+                message.Request.Headers.SetValue(UserAgentHeaderName, $"{userAgent} {value}");
+            }
+        }
+    }
+}
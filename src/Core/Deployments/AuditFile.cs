@@ -8,9 +8,58 @@ namespace Modm.Deployments
     {
         public override string FileName => "audit.json";
 
+        const int LockRetries = 50;
+        static readonly TimeSpan LockRetryDelay = TimeSpan.FromMilliseconds(100);
+        static readonly SemaphoreSlim semaphore = new(1, 1);
+
         public AuditFile(IConfiguration configuration, ILogger<AuditFile> logger)
             : base(configuration, logger)
         {
         }
+
+        /// <summary>
+        /// Appends a record to the audit file
+        /// </summary>
+        /// <param name="record"></param>
+        /// <param name="cancellationToken"></param>
+        /// <remarks>
+        /// The web host and the client app both write to the audit file, so the read and write are done while
+        /// holding an exclusive lock file rather than with <see cref="JsonFile{T}.ReadAsync"/> and <see cref="JsonFile{T}.WriteAsync"/>
+        /// </remarks>
+        public async Task AppendAsync(AuditRecord record, CancellationToken cancellationToken = default)
+        {
+            await semaphore.WaitAsync(cancellationToken);
+
+            try
+            {
+                using var lockFile = await AcquireLockAsync(cancellationToken);
+
+                var records = await ReadAsync(cancellationToken) ?? new List<AuditRecord>();
+                records.Add(record);
+
+                await WriteAsync(records, cancellationToken);
+            }
+            finally
+            {
+                semaphore.Release();
+            }
+        }
+
+        private async Task<FileStream> AcquireLockAsync(CancellationToken cancellationToken)
+        {
+            var path = $"{GetFilePath()}.lock";
+
+            for (var attempt = 1; ; attempt++)
+            {
+                try
+                {
+                    return new FileStream(path, FileMode.OpenOrCreate, FileAccess.ReadWrite, FileShare.None);
+                }
+                catch (IOException) when (attempt < LockRetries)
+                {
+                    await Task.Delay(LockRetryDelay, cancellationToken);
+                }
+            }
+        }
     }
 }
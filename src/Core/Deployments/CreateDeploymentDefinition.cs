@@ -5,6 +5,21 @@ namespace Modm.Deployments
 {
     public record CreateDeploymentDefinition : StartDeploymentRequest, IRequest<DeploymentDefinition>
 	{
+        /// <summary>
+        /// The findings of the parameter secret scan when it only warns, see <see cref="SecretScanningMode.Warn"/>
+        /// </summary>
+        public List<string> ParameterSecretWarnings { get; } = new();
+
+        /// <summary>
+        /// The names of the parameters the main template declares as secure
+        /// </summary>
+        public ISet<string> SecureParameterNames { get; set; } = new HashSet<string>();
+
+        /// <summary>
+        /// The values of the secure parameters, with Key Vault references resolved. Never persisted, only hashed
+        /// </summary>
+        public IDictionary<string, object> SecureParameterValues { get; set; }
+
         internal CreateDeploymentDefinition(StartDeploymentRequest request)
         {
             this.PackageUri = request.PackageUri;
@@ -1,5 +1,6 @@
 ﻿using System;
 using System.Text.Json.Serialization;
+using Modm.Diagnostics;
 
 namespace Modm.Deployments
 {
@@ -26,9 +27,27 @@ namespace Modm.Deployments
 
         public bool IsStartable { get; internal set; }
 
+        /// <summary>
+        /// Suggested remediation steps when the deployment has failed
+        /// </summary>
+        public IEnumerable<Remediation> Remediations { get; set; }
+
+        /// <summary>
+        /// The parameters that were changed since the previous attempt of the deployment
+        /// </summary>
+        public IEnumerable<ParameterChange> ParameterChanges { get; set; }
+
+        /// <summary>
+        /// The outputs of the deployment's template, captured when the deployment has completed
+        /// </summary>
+        public IDictionary<string, DeploymentOutput> Outputs { get; set; }
+
         public Deployment()
         {
             Resources = new List<DeploymentResource>();
+            Remediations = new List<Remediation>();
+            ParameterChanges = new List<ParameterChange>();
+            Outputs = new Dictionary<string, DeploymentOutput>();
         }
     }
 }
@@ -40,6 +40,31 @@ namespace Modm.Deployments
         [JsonConverter(typeof(DictionaryStringObjectJsonConverter))]
         public Dictionary<string, object> Parameters { get; set; }
 
+        /// <summary>
+        /// The salt of <see cref="SecureParameterHashes"/>
+        /// </summary>
+        public string SecureParameterSalt { get; set; }
+
+        /// <summary>
+        /// Salted hashes of the values of the secure parameters, whose values are redacted in <see cref="Parameters"/>
+        /// </summary>
+        public Dictionary<string, string> SecureParameterHashes { get; set; }
+
+        /// <summary>
+        /// The customer usage attribution GUID declared in the manifest
+        /// </summary>
+        public string PartnerId { get; set; }
+
+        /// <summary>
+        /// The prerequisites declared in the manifest
+        /// </summary>
+        public List<Prerequisite> Prerequisites { get; set; }
+
+        /// <summary>
+        /// The outputs declared in the manifest that the deployment must produce
+        /// </summary>
+        public List<ExpectedOutput> ExpectedOutputs { get; set; }
+
         /// <summary>
         /// Gets the fully qualified directory path where the main template is located
         /// </summary>
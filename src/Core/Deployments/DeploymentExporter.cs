@@ -0,0 +1,99 @@
+﻿using System.Text.RegularExpressions;
+using Modm.Compression;
+using Modm.Packaging;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Exports a deployment definition as an installer package, e.g. installer.zip, so the deployment
+    /// can be cloned into another environment
+    /// </summary>
+    /// <remarks>
+    /// The parameters file is rewritten with the values of secure parameters redacted. The terraform state and
+    /// the outputs written by the deployment job are excluded since they can contain secrets as well
+    /// </remarks>
+	public class DeploymentExporter
+	{
+        public const string RedactedValue = SecureParameters.RedactedValue;
+
+        static readonly string[] ExcludedDirectories = { ".terraform" };
+        static readonly Regex ExcludedFiles = new(@"\.tfstate(\.|$)", RegexOptions.Compiled);
+
+        private readonly ParametersFileFactory factory;
+        private readonly DirectoryZipper zipper;
+
+        public DeploymentExporter(ParametersFileFactory factory, DirectoryZipper zipper)
+		{
+            this.factory = factory;
+            this.zipper = zipper;
+		}
+
+        /// <summary>
+        /// Exports the deployment definition
+        /// </summary>
+        /// <param name="definition"></param>
+        /// <param name="cancellationToken"></param>
+        /// <returns>The fully qualified path of the exported installer package</returns>
+        public async Task<string> ExportAsync(DeploymentDefinition definition, CancellationToken cancellationToken = default)
+        {
+            var contentDirectory = Path.Combine(Path.GetTempPath(), $"modm-export-{Guid.NewGuid()}");
+            var packagePath = Path.Combine(Path.GetTempPath(), $"{Guid.NewGuid()}-{PackageFile.FileName}");
+
+            try
+            {
+                CopyDirectory(definition.WorkingDirectory, contentDirectory);
+                File.Delete(Path.Combine(contentDirectory, DeploymentOutput.FileName));
+
+                var templateDirectory = Path.GetDirectoryName(Path.Combine(contentDirectory, definition.MainTemplatePath));
+                var file = factory.Create(definition.DeploymentType, templateDirectory);
+
+                var mainTemplatePath = Path.Combine(templateDirectory, Path.GetFileName(definition.MainTemplatePath));
+                var secureParameters = await SecureParameters.GetNamesAsync(definition.DeploymentType, mainTemplatePath, cancellationToken);
+                await file.Write(SecureParameters.Redact(definition.Parameters, secureParameters));
+
+                zipper.ZipDirectory(contentDirectory, packagePath);
+            }
+            catch
+            {
+                if (File.Exists(packagePath))
+                {
+                    File.Delete(packagePath);
+                }
+
+                throw;
+            }
+            finally
+            {
+                if (Directory.Exists(contentDirectory))
+                {
+                    Directory.Delete(contentDirectory, true);
+                }
+            }
+
+            return packagePath;
+        }
+
+        private static void CopyDirectory(string sourceDirectory, string destinationDirectory)
+        {
+            Directory.CreateDirectory(destinationDirectory);
+
+            foreach (var file in Directory.EnumerateFiles(sourceDirectory))
+            {
+                if (!ExcludedFiles.IsMatch(Path.GetFileName(file)))
+                {
+                    File.Copy(file, Path.Combine(destinationDirectory, Path.GetFileName(file)));
+                }
+            }
+
+            foreach (var directory in Directory.EnumerateDirectories(sourceDirectory))
+            {
+                var name = Path.GetFileName(directory);
+
+                if (!ExcludedDirectories.Contains(name))
+                {
+                    CopyDirectory(directory, Path.Combine(destinationDirectory, name));
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,59 @@
+﻿using System.Text.Json;
+using System.Text.Json.Serialization;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// An output of a completed deployment, e.g. a hostname or connection string declared in the template's outputs
+    /// </summary>
+    public record DeploymentOutput
+    {
+        /// <summary>
+        /// The name of the file the deployment job writes the outputs to in the working directory
+        /// </summary>
+        public const string FileName = "outputs.json";
+
+        [JsonPropertyName("type")]
+        public JsonElement Type { get; init; }
+
+        [JsonPropertyName("value")]
+        public JsonElement Value { get; init; }
+
+        /// <summary>
+        /// Whether terraform declares the output as sensitive, in which case the job strips its value
+        /// </summary>
+        [JsonPropertyName("sensitive")]
+        public bool Sensitive { get; init; }
+
+        /// <summary>
+        /// Reads the outputs written by the deployment job
+        /// </summary>
+        /// <param name="workingDirectory">The working directory of the deployment definition</param>
+        /// <param name="cancellationToken"></param>
+        /// <returns>The outputs by name. Empty if the job wrote none</returns>
+        /// <remarks>
+        /// Both ARM (az deployment group show) and terraform (terraform output -json) describe an output by its type and value.
+        /// The job keeps sensitive terraform outputs with a null value, so they can still be verified against the manifest
+        /// </remarks>
+        public static async Task<Dictionary<string, DeploymentOutput>> ReadAsync(string workingDirectory, CancellationToken cancellationToken = default)
+        {
+            var path = Path.Combine(workingDirectory ?? string.Empty, FileName);
+
+            if (!File.Exists(path))
+            {
+                return new Dictionary<string, DeploymentOutput>();
+            }
+
+            using var stream = File.OpenRead(path);
+
+            // a deployment without outputs returns null
+            if (stream.Length == 0)
+            {
+                return new Dictionary<string, DeploymentOutput>();
+            }
+
+            var outputs = await JsonSerializer.DeserializeAsync<Dictionary<string, DeploymentOutput>>(stream, cancellationToken: cancellationToken);
+            return outputs ?? new Dictionary<string, DeploymentOutput>();
+        }
+    }
+}
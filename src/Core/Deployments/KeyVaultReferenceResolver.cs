@@ -0,0 +1,130 @@
+﻿using System.Collections.Concurrent;
+using System.Text.Json;
+using System.Text.RegularExpressions;
+using Azure.Security.KeyVault.Secrets;
+using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
+using Modm.Http;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Resolves parameters that reference a Key Vault secret, e.g. @keyvault(https://contoso.vault.azure.net/secrets/adminPassword),
+    /// to the value of the secret using the installer's managed identity
+    /// </summary>
+    /// <remarks>
+    /// Only the parameters file handed to the deployment receives the secret values. The deployment file and
+    /// audit records keep the reference
+    /// </remarks>
+	public class KeyVaultReferenceResolver
+	{
+        static readonly Regex Reference = new(@"^@keyvault\((?<id>https://[^)\s]+)\)$", RegexOptions.Compiled | RegexOptions.IgnoreCase);
+
+        private readonly OutboundHttpOptions options;
+        private readonly ILogger<KeyVaultReferenceResolver> logger;
+        private readonly ConcurrentDictionary<Uri, SecretClient> clients = new();
+
+        /// <summary>
+        /// Constructor without params only to support testing
+        /// </summary>
+        public KeyVaultReferenceResolver()
+        {
+        }
+
+        public KeyVaultReferenceResolver(IOptions<OutboundHttpOptions> options, ILogger<KeyVaultReferenceResolver> logger)
+		{
+            this.options = options.Value;
+            this.logger = logger;
+		}
+
+        /// <summary>
+        /// Whether the value is a Key Vault reference
+        /// </summary>
+        public static bool IsReference(object value)
+        {
+            return TryParse(value, out _);
+        }
+
+        /// <summary>
+        /// Resolves the Key Vault references of the parameters
+        /// </summary>
+        /// <param name="parameters"></param>
+        /// <param name="cancellationToken"></param>
+        /// <returns>A copy of the parameters with the references replaced by the values of the secrets</returns>
+        public virtual async Task<IDictionary<string, object>> ResolveAsync(IDictionary<string, object> parameters, CancellationToken cancellationToken = default)
+        {
+            var resolved = new Dictionary<string, object>();
+
+            foreach (var parameter in parameters ?? new Dictionary<string, object>())
+            {
+                if (!TryParse(parameter.Value, out var secretId))
+                {
+                    resolved[parameter.Key] = parameter.Value;
+                    continue;
+                }
+
+                logger.LogInformation("Resolving parameter {name} from Key Vault {vault}", parameter.Key, secretId.VaultUri);
+
+                try
+                {
+                    resolved[parameter.Key] = await GetSecretAsync(secretId, cancellationToken);
+                }
+                catch (Exception ex) when (ex is not OperationCanceledException)
+                {
+                    throw new InvalidOperationException($"Failed to resolve the Key Vault reference of parameter '{parameter.Key}'. {ex.Message}", ex);
+                }
+            }
+
+            return resolved;
+        }
+
+        protected virtual async Task<string> GetSecretAsync(KeyVaultSecretIdentifier secretId, CancellationToken cancellationToken)
+        {
+            var client = clients.GetOrAdd(secretId.VaultUri, CreateClient);
+            var response = await client.GetSecretAsync(secretId.Name, secretId.Version, cancellationToken);
+
+            return response.Value.Value;
+        }
+
+        private SecretClient CreateClient(Uri vaultUri)
+        {
+            var clientOptions = new SecretClientOptions();
+
+            if (options?.IsConfigured == true)
+            {
+                clientOptions.Transport = OutboundHttpHandler.CreateTransport(options);
+            }
+
+            return new SecretClient(vaultUri, OutboundHttpHandler.CreateCredential(options), clientOptions);
+        }
+
+        internal static bool TryParse(object value, out KeyVaultSecretIdentifier secretId)
+        {
+            secretId = default;
+
+            var text = value switch
+            {
+                string s => s,
+                JsonElement e when e.ValueKind == JsonValueKind.String => e.GetString(),
+                _ => null
+            };
+
+            var match = text == null ? null : Reference.Match(text.Trim());
+
+            if (match == null || !match.Success)
+            {
+                return false;
+            }
+
+            try
+            {
+                secretId = new KeyVaultSecretIdentifier(new Uri(match.Groups["id"].Value));
+                return true;
+            }
+            catch (Exception ex) when (ex is ArgumentException or UriFormatException)
+            {
+                return false;
+            }
+        }
+	}
+}
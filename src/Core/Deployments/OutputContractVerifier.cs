@@ -0,0 +1,81 @@
+﻿using System.Text.Json;
+using Modm.Packaging;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Verifies the outputs of a completed deployment against the outputs declared in the manifest, so a template that
+    /// drifted from what the offer promises fails the deployment rather than silently going unnoticed
+    /// </summary>
+	public static class OutputContractVerifier
+	{
+        /// <summary>
+        /// Verifies the outputs
+        /// </summary>
+        /// <param name="expectedOutputs">The outputs declared in the manifest</param>
+        /// <param name="outputs">The outputs captured from the deployment</param>
+        /// <returns>The list of violations. Empty if the contract is satisfied</returns>
+        /// <remarks>
+        /// ARM and terraform name the same types differently, e.g. Int and number, so types are compared by kind
+        /// </remarks>
+        public static List<string> Verify(IEnumerable<ExpectedOutput> expectedOutputs, IDictionary<string, DeploymentOutput> outputs)
+        {
+            var violations = new List<string>();
+
+            if (expectedOutputs == null)
+            {
+                return violations;
+            }
+
+            outputs = new Dictionary<string, DeploymentOutput>(outputs ?? new Dictionary<string, DeploymentOutput>(), StringComparer.OrdinalIgnoreCase);
+
+            foreach (var expected in expectedOutputs)
+            {
+                if (!outputs.TryGetValue(expected.Name, out var output))
+                {
+                    violations.Add($"Expected output '{expected.Name}' was not produced by the deployment");
+                    continue;
+                }
+
+                if (string.IsNullOrEmpty(expected.Type))
+                {
+                    continue;
+                }
+
+                var actualType = GetTypeName(output.Type);
+
+                if (GetKind(expected.Type) != GetKind(actualType))
+                {
+                    violations.Add($"Expected output '{expected.Name}' to be of type '{expected.Type}' but was '{actualType}'");
+                }
+            }
+
+            return violations;
+        }
+
+        /// <summary>
+        /// Gets the name of the output's type. Terraform describes complex types as an array, e.g. ["list", "string"]
+        /// </summary>
+        private static string GetTypeName(JsonElement type)
+        {
+            return type.ValueKind switch
+            {
+                JsonValueKind.String => type.GetString(),
+                JsonValueKind.Array when type.GetArrayLength() > 0 && type[0].ValueKind == JsonValueKind.String => type[0].GetString(),
+                _ => type.ToString()
+            };
+        }
+
+        private static string GetKind(string type)
+        {
+            return type?.ToLowerInvariant() switch
+            {
+                "securestring" => "string",
+                "int" or "number" => "number",
+                "list" or "tuple" or "set" => "array",
+                "map" or "secureobject" => "object",
+                var kind => kind
+            };
+        }
+    }
+}
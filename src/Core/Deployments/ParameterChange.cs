@@ -0,0 +1,72 @@
+﻿using System.Text.Json;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// A change to a deployment parameter between two attempts of a deployment
+    /// </summary>
+    public record ParameterChange
+    {
+        public string Name { get; init; }
+
+        /// <summary>
+        /// The value of the previous attempt, null if the parameter was added. Redacted for secure parameters
+        /// </summary>
+        public object PreviousValue { get; init; }
+
+        /// <summary>
+        /// The value of the current attempt, null if the parameter was removed. Redacted for secure parameters
+        /// </summary>
+        public object Value { get; init; }
+
+        /// <summary>
+        /// Compares the parameters of two deployment attempts
+        /// </summary>
+        /// <param name="previous">The parameters of the previous attempt</param>
+        /// <param name="current">The parameters of the current attempt</param>
+        /// <param name="secureParameters">The names of the secure parameters, whose values are only reported as changed</param>
+        /// <param name="previousHashes">The hashes of the secure values of the previous attempt, see <see cref="SecureParameters.Hash"/></param>
+        /// <param name="currentHashes">The hashes of the secure values of the current attempt, with the salt of the previous attempt</param>
+        /// <returns>The changed parameters, ordered by name</returns>
+        public static List<ParameterChange> Compare(IDictionary<string, object> previous, IDictionary<string, object> current, ISet<string> secureParameters = null,
+            IDictionary<string, string> previousHashes = null, IDictionary<string, string> currentHashes = null)
+        {
+            previous ??= new Dictionary<string, object>();
+            current ??= new Dictionary<string, object>();
+            secureParameters ??= new HashSet<string>();
+
+            // redacted secure values are compared by their hashes
+            if (previousHashes != null && currentHashes != null)
+            {
+                previous = WithHashes(previous, previousHashes);
+                current = WithHashes(current, currentHashes);
+            }
+
+            return previous.Keys.Union(current.Keys)
+                .Select(name => new ParameterChange
+                {
+                    Name = name,
+                    PreviousValue = previous.TryGetValue(name, out var previousValue) ? previousValue : null,
+                    Value = current.TryGetValue(name, out var value) ? value : null
+                })
+                .Where(change => JsonSerializer.Serialize(change.PreviousValue) != JsonSerializer.Serialize(change.Value))
+                .Select(change => secureParameters.Contains(change.Name) ? Redact(change) : change)
+                .OrderBy(change => change.Name)
+                .ToList();
+        }
+
+        private static IDictionary<string, object> WithHashes(IDictionary<string, object> parameters, IDictionary<string, string> hashes)
+        {
+            return parameters.ToDictionary(p => p.Key, p => hashes.TryGetValue(p.Key, out var hash) ? hash : p.Value);
+        }
+
+        private static ParameterChange Redact(ParameterChange change)
+        {
+            return change with
+            {
+                PreviousValue = change.PreviousValue == null ? null : SecureParameters.RedactedValue,
+                Value = change.Value == null ? null : SecureParameters.RedactedValue
+            };
+        }
+    }
+}
@@ -0,0 +1,74 @@
+﻿using System.Text.Encodings.Web;
+using System.Text.Json;
+using System.Text.RegularExpressions;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Scans deployment parameters for values that look like secrets, e.g. connection strings or keys, passed to
+    /// parameters that aren't secure and would otherwise be stored in plain text
+    /// </summary>
+	public static class ParameterSecretScanner
+	{
+        static readonly JsonSerializerOptions SerializerOptions = new() { Encoder = JavaScriptEncoder.UnsafeRelaxedJsonEscaping };
+
+        static readonly Dictionary<string, Regex> Patterns = new()
+        {
+            { "connection string secret", new(@"(?i)\b(AccountKey|SharedAccessKey|Password|Pwd)\s*=\s*[^;\s""]{4,}", RegexOptions.Compiled) },
+            { "private key", new(@"-----BEGIN [A-Z ]*PRIVATE KEY-----", RegexOptions.Compiled) },
+            { "SAS token", new(@"(?i)[?&]sig=[A-Za-z0-9%+/=]{20,}", RegexOptions.Compiled) },
+            { "JSON web token", new(@"\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}", RegexOptions.Compiled) },
+            { "storage account key", new(@"(?<![A-Za-z0-9+/])[A-Za-z0-9+/]{86}==", RegexOptions.Compiled) },
+            { "AWS access key", new(@"\bAKIA[0-9A-Z]{16}\b", RegexOptions.Compiled) },
+            { "GitHub token", new(@"\bgh[pousr]_[A-Za-z0-9]{36}\b", RegexOptions.Compiled) }
+        };
+
+        /// <summary>
+        /// Scans the parameters
+        /// </summary>
+        /// <param name="parameters"></param>
+        /// <param name="secureParameters">The names of the parameters declared as secure, which are not scanned</param>
+        /// <returns>A finding per parameter that looks like a secret, naming the parameter but never the value</returns>
+        public static List<string> Scan(IDictionary<string, object> parameters, ISet<string> secureParameters)
+        {
+            var findings = new List<string>();
+
+            if (parameters == null)
+            {
+                return findings;
+            }
+
+            foreach (var parameter in parameters.OrderBy(p => p.Key))
+            {
+                if (parameter.Value == null || (secureParameters?.Contains(parameter.Key) ?? false))
+                {
+                    continue;
+                }
+
+                var value = ToString(parameter.Value);
+                var kind = Patterns.FirstOrDefault(p => p.Value.IsMatch(value)).Key;
+
+                if (kind != null)
+                {
+                    findings.Add($"Parameter '{parameter.Key}' looks like it contains a {kind} but is not a secure parameter.");
+                }
+            }
+
+            return findings;
+        }
+
+        /// <summary>
+        /// objects and arrays are scanned as json so nested values are included
+        /// </summary>
+        private static string ToString(object value)
+        {
+            return value switch
+            {
+                string s => s,
+                JsonElement e when e.ValueKind == JsonValueKind.String => e.GetString(),
+                JsonElement e => e.GetRawText(),
+                _ => JsonSerializer.Serialize(value, SerializerOptions)
+            };
+        }
+	}
+}
@@ -0,0 +1,123 @@
+﻿using Azure;
+using Azure.Core;
+using Azure.ResourceManager;
+using Azure.ResourceManager.Authorization;
+using Microsoft.Extensions.Logging;
+using Modm.Azure;
+using Modm.Packaging;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Verifies the prerequisites declared in the manifest of the installer package
+    /// </summary>
+	public class PrerequisitesVerifier
+	{
+        const string RegisteredState = "Registered";
+
+        private readonly ArmClient client;
+        private readonly IManagedIdentityService managedIdentityService;
+        private readonly ILogger<PrerequisitesVerifier> logger;
+
+        /// <summary>
+        /// Constructor without params only to support testing
+        /// </summary>
+        public PrerequisitesVerifier()
+        {
+        }
+
+        public PrerequisitesVerifier(ArmClient client, IManagedIdentityService managedIdentityService, ILogger<PrerequisitesVerifier> logger)
+		{
+            this.client = client;
+            this.managedIdentityService = managedIdentityService;
+            this.logger = logger;
+		}
+
+        /// <summary>
+        /// Verifies the prerequisites
+        /// </summary>
+        /// <param name="prerequisites"></param>
+        /// <param name="cancellationToken"></param>
+        /// <returns>The list of unmet prerequisites. Empty if all are satisfied</returns>
+        public virtual async Task<List<string>> VerifyAsync(IEnumerable<Prerequisite> prerequisites, CancellationToken cancellationToken = default)
+        {
+            var unmet = new List<string>();
+
+            if (prerequisites == null)
+            {
+                return unmet;
+            }
+
+            foreach (var prerequisite in prerequisites)
+            {
+                try
+                {
+                    if (!await IsSatisfiedAsync(prerequisite, cancellationToken))
+                    {
+                        unmet.Add($"Unmet prerequisite: {prerequisite}");
+                    }
+                }
+                catch (Exception ex)
+                {
+                    logger.LogError(ex, "Failed to verify prerequisite {prerequisite}", prerequisite);
+                    unmet.Add($"Unable to verify prerequisite: {prerequisite}. {ex.Message}");
+                }
+            }
+
+            return unmet;
+        }
+
+        private Task<bool> IsSatisfiedAsync(Prerequisite prerequisite, CancellationToken cancellationToken)
+        {
+            return prerequisite.Type switch
+            {
+                PrerequisiteType.Resource => ResourceExistsAsync(prerequisite, cancellationToken),
+                PrerequisiteType.ResourceProvider => IsProviderRegisteredAsync(prerequisite, cancellationToken),
+                PrerequisiteType.RoleAssignment => IsRoleAssignedAsync(prerequisite, cancellationToken),
+                _ => throw new ArgumentOutOfRangeException(nameof(prerequisite), $"prerequisite type '{prerequisite.Type}' is not supported.")
+            };
+        }
+
+        private async Task<bool> ResourceExistsAsync(Prerequisite prerequisite, CancellationToken cancellationToken)
+        {
+            var resource = client.GetGenericResource(new ResourceIdentifier(prerequisite.ResourceId));
+
+            try
+            {
+                await resource.GetAsync(cancellationToken);
+                return true;
+            }
+            catch (RequestFailedException ex) when (ex.Status == 404)
+            {
+                return false;
+            }
+        }
+
+        private async Task<bool> IsProviderRegisteredAsync(Prerequisite prerequisite, CancellationToken cancellationToken)
+        {
+            var subscription = await client.GetDefaultSubscriptionAsync(cancellationToken);
+            var response = await subscription.GetResourceProviderAsync(prerequisite.Namespace, cancellationToken: cancellationToken);
+
+            return string.Equals(response.Value.Data.RegistrationState, RegisteredState, StringComparison.OrdinalIgnoreCase);
+        }
+
+        private async Task<bool> IsRoleAssignedAsync(Prerequisite prerequisite, CancellationToken cancellationToken)
+        {
+            var identity = await managedIdentityService.GetAsync(cancellationToken);
+            var roleDefinitionName = prerequisite.RoleDefinitionId.Split('/').Last();
+
+            var assignments = client.GetRoleAssignments(new ResourceIdentifier(prerequisite.Scope));
+            var filter = $"principalId eq '{identity.ObjectId}'";
+
+            await foreach (var assignment in assignments.GetAllAsync(filter: filter, cancellationToken: cancellationToken))
+            {
+                if (string.Equals(assignment.Data.RoleDefinitionId?.Name, roleDefinitionName, StringComparison.OrdinalIgnoreCase))
+                {
+                    return true;
+                }
+            }
+
+            return false;
+        }
+	}
+}
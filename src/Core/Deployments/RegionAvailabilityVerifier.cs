@@ -0,0 +1,341 @@
+﻿using System.Text.Json;
+using System.Text.RegularExpressions;
+using Azure.ResourceManager;
+using Azure.ResourceManager.Compute;
+using Azure.ResourceManager.Compute.Models;
+using Azure.ResourceManager.Resources;
+using Microsoft.Extensions.Logging;
+using Modm.Azure;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Verifies that the resource types of an ARM template, and the sizes of its virtual machines, are available in the region being deployed to
+    /// </summary>
+	public class RegionAvailabilityVerifier
+	{
+        const string LocationParameterName = "location";
+        const int MaxSuggestedRegions = 5;
+        const string VirtualMachineSkuType = "virtualMachines";
+
+        static readonly Regex ParameterExpression = new(@"^\[parameters\('(?<name>[^']+)'\)\]$", RegexOptions.Compiled);
+
+        private readonly ArmClient client;
+        private readonly IMetadataService metadataService;
+        private readonly ILogger<RegionAvailabilityVerifier> logger;
+
+        /// <summary>
+        /// Constructor without params only to support testing
+        /// </summary>
+        public RegionAvailabilityVerifier()
+        {
+        }
+
+        public RegionAvailabilityVerifier(ArmClient client, IMetadataService metadataService, ILogger<RegionAvailabilityVerifier> logger)
+		{
+            this.client = client;
+            this.metadataService = metadataService;
+            this.logger = logger;
+		}
+
+        /// <summary>
+        /// Verifies the region availability of the resource types in the definition's main template
+        /// </summary>
+        /// <param name="definition"></param>
+        /// <param name="cancellationToken"></param>
+        /// <returns>The list of resource types and virtual machine sizes unavailable in the region. Empty if all are available</returns>
+        /// <remarks>
+        /// Terraform templates are not verified since their resource types don't map directly to resource provider types.
+        /// Only the SKUs of virtual machines and scale sets are verified, other resource SKUs aren't.
+        /// A failure to verify, e.g. when ARM throttles, is logged and doesn't block the deployment
+        /// </remarks>
+        public virtual async Task<List<string>> VerifyAsync(DeploymentDefinition definition, CancellationToken cancellationToken = default)
+        {
+            var unavailable = new List<string>();
+
+            if (definition?.DeploymentType != DeploymentType.Arm)
+            {
+                return unavailable;
+            }
+
+            var template = await File.ReadAllTextAsync(Path.Combine(definition.WorkingDirectory, definition.MainTemplatePath), cancellationToken);
+            string location;
+            SubscriptionResource subscription;
+
+            try
+            {
+                location = Normalize(await GetLocationAsync(definition));
+                subscription = await client.GetDefaultSubscriptionAsync(cancellationToken);
+            }
+            catch (Exception ex)
+            {
+                logger.LogWarning(ex, "Unable to verify region availability");
+                return unavailable;
+            }
+
+            unavailable.AddRange(await VerifyResourceTypesAsync(subscription, template, location, cancellationToken));
+            unavailable.AddRange(await VerifyVirtualMachineSizesAsync(subscription, GetVirtualMachineSizes(template, definition.Parameters), location, cancellationToken));
+
+            return unavailable;
+        }
+
+        private async Task<List<string>> VerifyResourceTypesAsync(SubscriptionResource subscription, string template, string location, CancellationToken cancellationToken)
+        {
+            var unavailable = new List<string>();
+
+            foreach (var group in GetResourceTypes(template).GroupBy(t => t.Split('/')[0], StringComparer.OrdinalIgnoreCase))
+            {
+                try
+                {
+                    var response = await subscription.GetResourceProviderAsync(group.Key, cancellationToken: cancellationToken);
+                    var providerTypes = response.Value.Data.ResourceTypes;
+
+                    foreach (var resourceType in group)
+                    {
+                        var typeName = resourceType[(group.Key.Length + 1)..];
+                        var providerType = providerTypes.FirstOrDefault(t => string.Equals(t.ResourceType, typeName, StringComparison.OrdinalIgnoreCase));
+
+                        // global resource types have no locations
+                        if (providerType == null || providerType.Locations.Count == 0)
+                        {
+                            continue;
+                        }
+
+                        if (!providerType.Locations.Any(l => Normalize(l) == location))
+                        {
+                            var suggested = string.Join(", ", providerType.Locations.Take(MaxSuggestedRegions));
+                            unavailable.Add($"Resource type {resourceType} is not available in region '{location}'. Available regions include: {suggested}");
+                        }
+                    }
+                }
+                catch (Exception ex)
+                {
+                    // the deployment itself reports the resource types that are unavailable
+                    logger.LogWarning(ex, "Unable to verify region availability of resource provider {namespace}", group.Key);
+                }
+            }
+
+            return unavailable;
+        }
+
+        private async Task<List<string>> VerifyVirtualMachineSizesAsync(SubscriptionResource subscription, ISet<string> sizes, string location, CancellationToken cancellationToken)
+        {
+            var unavailable = new List<string>();
+
+            if (sizes.Count == 0)
+            {
+                return unavailable;
+            }
+
+            try
+            {
+                var offered = new HashSet<string>(StringComparer.OrdinalIgnoreCase);
+
+                await foreach (var sku in subscription.GetComputeResourceSkusAsync(filter: $"location eq '{location}'", cancellationToken: cancellationToken))
+                {
+                    if (!string.Equals(sku.ResourceType, VirtualMachineSkuType, StringComparison.OrdinalIgnoreCase) || !sizes.Contains(sku.Name))
+                    {
+                        continue;
+                    }
+
+                    offered.Add(sku.Name);
+
+                    var restriction = sku.Restrictions.FirstOrDefault(r => r.RestrictionsType == ComputeResourceSkuRestrictionsType.Location);
+
+                    if (restriction != null)
+                    {
+                        unavailable.Add($"Virtual machine size {sku.Name} is restricted in region '{location}' for this subscription ({restriction.ReasonCode}).");
+                    }
+                }
+
+                foreach (var size in sizes.Where(size => !offered.Contains(size)))
+                {
+                    unavailable.Add($"Virtual machine size {size} is not available in region '{location}'.");
+                }
+            }
+            catch (Exception ex)
+            {
+                logger.LogWarning(ex, "Unable to verify the availability of virtual machine sizes in region {location}", location);
+            }
+
+            return unavailable;
+        }
+
+        /// <summary>
+        /// Gets the distinct sizes of the virtual machines and scale sets of a template, including those of nested deployment templates
+        /// </summary>
+        /// <param name="template">The template json</param>
+        /// <param name="parameters">The deployment parameters, used to resolve sizes set by a parameter</param>
+        /// <remarks>
+        /// Sizes set by other expressions can't be resolved before the deployment and are skipped
+        /// </remarks>
+        internal static ISet<string> GetVirtualMachineSizes(string template, IDictionary<string, object> parameters)
+        {
+            using var document = JsonDocument.Parse(template);
+
+            var sizes = new HashSet<string>(StringComparer.OrdinalIgnoreCase);
+            AddVirtualMachineSizes(document.RootElement, parameters ?? new Dictionary<string, object>(), sizes);
+
+            return sizes;
+        }
+
+        private static void AddVirtualMachineSizes(JsonElement template, IDictionary<string, object> parameters, HashSet<string> sizes)
+        {
+            if (!template.TryGetProperty("resources", out var resources))
+            {
+                return;
+            }
+
+            var items = resources.ValueKind == JsonValueKind.Object
+                ? resources.EnumerateObject().Select(p => p.Value)
+                : resources.ValueKind == JsonValueKind.Array ? resources.EnumerateArray() : Enumerable.Empty<JsonElement>();
+
+            foreach (var resource in items)
+            {
+                var type = resource.TryGetProperty("type", out var typeElement) && typeElement.ValueKind == JsonValueKind.String ? typeElement.GetString() : null;
+                JsonElement size = default;
+
+                var hasSize = string.Equals(type, "Microsoft.Compute/virtualMachines", StringComparison.OrdinalIgnoreCase)
+                    ? TryGetPath(resource, out size, "properties", "hardwareProfile", "vmSize")
+                    : string.Equals(type, "Microsoft.Compute/virtualMachineScaleSets", StringComparison.OrdinalIgnoreCase) && TryGetPath(resource, out size, "sku", "name");
+
+                var value = hasSize ? Resolve(size, parameters, template) : null;
+
+                if (!string.IsNullOrEmpty(value))
+                {
+                    sizes.Add(value);
+                }
+
+                // parameters of nested templates are scoped to the nested deployment, so they can't be resolved
+                if (TryGetPath(resource, out var nestedTemplate, "properties", "template"))
+                {
+                    AddVirtualMachineSizes(nestedTemplate, new Dictionary<string, object>(), sizes);
+                }
+            }
+        }
+
+        private static string Resolve(JsonElement value, IDictionary<string, object> parameters, JsonElement template)
+        {
+            if (value.ValueKind != JsonValueKind.String)
+            {
+                return null;
+            }
+
+            var text = value.GetString();
+
+            if (!text.StartsWith("["))
+            {
+                return text;
+            }
+
+            var match = ParameterExpression.Match(text);
+
+            if (!match.Success)
+            {
+                return null;
+            }
+
+            var name = match.Groups["name"].Value;
+
+            if (parameters.TryGetValue(name, out var parameterValue))
+            {
+                return parameterValue is string size && !size.StartsWith("[") ? size : null;
+            }
+
+            return TryGetPath(template, out var defaultValue, "parameters", name, "defaultValue") && defaultValue.ValueKind == JsonValueKind.String
+                ? defaultValue.GetString()
+                : null;
+        }
+
+        private static bool TryGetPath(JsonElement element, out JsonElement value, params string[] path)
+        {
+            value = element;
+
+            foreach (var name in path)
+            {
+                if (value.ValueKind != JsonValueKind.Object || !value.TryGetProperty(name, out value))
+                {
+                    return false;
+                }
+            }
+
+            return true;
+        }
+
+        /// <summary>
+        /// Gets the distinct resource types of a template, including those of nested deployment templates
+        /// </summary>
+        /// <param name="template">The template json</param>
+        internal static IEnumerable<string> GetResourceTypes(string template)
+        {
+            using var document = JsonDocument.Parse(template);
+
+            var types = new HashSet<string>(StringComparer.OrdinalIgnoreCase);
+            AddResourceTypes(document.RootElement, types, null);
+
+            return types;
+        }
+
+        private static void AddResourceTypes(JsonElement element, HashSet<string> types, string parentType)
+        {
+            if (!element.TryGetProperty("resources", out var resources))
+            {
+                return;
+            }
+
+            // languageVersion 2.0 templates declare resources by symbolic name
+            var items = resources.ValueKind == JsonValueKind.Object
+                ? resources.EnumerateObject().Select(p => p.Value)
+                : resources.ValueKind == JsonValueKind.Array ? resources.EnumerateArray() : Enumerable.Empty<JsonElement>();
+
+            foreach (var resource in items)
+            {
+                if (!resource.TryGetProperty("type", out var typeElement) || typeElement.ValueKind != JsonValueKind.String)
+                {
+                    continue;
+                }
+
+                var type = typeElement.GetString();
+
+                // child resources can be declared with a type relative to their parent
+                if (parentType != null && !type.Contains('/'))
+                {
+                    type = $"{parentType}/{type}";
+                }
+
+                if (!type.StartsWith("["))
+                {
+                    types.Add(type);
+                }
+
+                AddResourceTypes(resource, types, type);
+
+                if (resource.TryGetProperty("properties", out var properties) &&
+                    properties.ValueKind == JsonValueKind.Object &&
+                    properties.TryGetProperty("template", out var nestedTemplate))
+                {
+                    AddResourceTypes(nestedTemplate, types, null);
+                }
+            }
+        }
+
+        private async Task<string> GetLocationAsync(DeploymentDefinition definition)
+        {
+            if (definition.Parameters != null &&
+                definition.Parameters.TryGetValue(LocationParameterName, out var value) &&
+                value is string location && !location.StartsWith("["))
+            {
+                return location;
+            }
+
+            // default to the region the installer is deployed to
+            var metadata = await metadataService.GetAsync();
+            return metadata.Compute.Location;
+        }
+
+        private static string Normalize(string location)
+        {
+            return location?.Replace(" ", string.Empty).ToLowerInvariant();
+        }
+    }
+}
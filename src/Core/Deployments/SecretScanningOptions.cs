@@ -0,0 +1,21 @@
+﻿using System;
+
+namespace Modm.Deployments
+{
+    public enum SecretScanningMode
+    {
+        Off,
+        Warn,
+        Block
+    }
+
+	public class SecretScanningOptions
+	{
+        public const string ConfigSectionKey = "SecretScanning";
+
+        /// <summary>
+        /// Whether parameters that look like secrets but are not secure parameters are reported as warnings or block the deployment
+        /// </summary>
+        public SecretScanningMode Mode { get; set; } = SecretScanningMode.Warn;
+	}
+}
@@ -0,0 +1,170 @@
+﻿using System.Security.Cryptography;
+using System.Text;
+using System.Text.Json;
+using System.Text.RegularExpressions;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Reads the names of the parameters a template declares as secure, i.e. secureString and secureObject ARM
+    /// parameters or sensitive terraform variables
+    /// </summary>
+	public static class SecureParameters
+	{
+        /// <summary>
+        /// The value that replaces the value of a secure parameter wherever it is exposed
+        /// </summary>
+        public const string RedactedValue = "***";
+
+        const int HashIterations = 100_000;
+
+        static readonly Regex VariableBlock = new(@"\bvariable\s+""(?<name>[^""]+)""\s*\{", RegexOptions.Compiled);
+        static readonly Regex SensitiveAttribute = new(@"\bsensitive\s*=\s*true\b", RegexOptions.Compiled);
+        static readonly string[] SecureArmTypes = { "secureString", "secureObject" };
+
+        /// <summary>
+        /// Gets the names of the secure parameters
+        /// </summary>
+        /// <param name="deploymentType"></param>
+        /// <param name="mainTemplatePath">The fully qualified path of the main template</param>
+        /// <param name="cancellationToken"></param>
+        public static async Task<ISet<string>> GetNamesAsync(string deploymentType, string mainTemplatePath, CancellationToken cancellationToken = default)
+        {
+            var names = new HashSet<string>(StringComparer.OrdinalIgnoreCase);
+
+            if (deploymentType == DeploymentType.Terraform)
+            {
+                foreach (var path in Directory.EnumerateFiles(Path.GetDirectoryName(mainTemplatePath), "*.tf"))
+                {
+                    var content = await File.ReadAllTextAsync(path, cancellationToken);
+                    names.UnionWith(GetSensitiveVariableNames(content));
+                }
+
+                return names;
+            }
+
+            using var stream = File.OpenRead(mainTemplatePath);
+            using var document = await JsonDocument.ParseAsync(stream, cancellationToken: cancellationToken);
+
+            if (document.RootElement.TryGetProperty("parameters", out var parameters))
+            {
+                foreach (var parameter in parameters.EnumerateObject())
+                {
+                    if (parameter.Value.TryGetProperty("type", out var type) &&
+                        SecureArmTypes.Contains(type.GetString(), StringComparer.OrdinalIgnoreCase))
+                    {
+                        names.Add(parameter.Name);
+                    }
+                }
+            }
+
+            return names;
+        }
+
+        /// <summary>
+        /// Gets the names of the variables of a terraform file whose block sets sensitive = true
+        /// </summary>
+        internal static IEnumerable<string> GetSensitiveVariableNames(string content)
+        {
+            foreach (Match match in VariableBlock.Matches(content))
+            {
+                if (SensitiveAttribute.IsMatch(ReadBlockAttributes(content, match.Index + match.Length)))
+                {
+                    yield return match.Groups["name"].Value;
+                }
+            }
+        }
+
+        /// <summary>
+        /// Reads the body of a block up to its balanced closing brace, keeping only its own attributes, i.e. without
+        /// nested blocks such as validation, object types, strings and comments
+        /// </summary>
+        /// <param name="content"></param>
+        /// <param name="start">The index after the opening brace of the block</param>
+        private static string ReadBlockAttributes(string content, int start)
+        {
+            var attributes = new StringBuilder();
+            var depth = 1;
+
+            for (var i = start; i < content.Length && depth > 0; i++)
+            {
+                var c = content[i];
+                var next = i + 1 < content.Length ? content[i + 1] : '\0';
+
+                if (c == '"')
+                {
+                    // braces of interpolations, e.g. "${var.name}", belong to the string
+                    for (i++; i < content.Length && content[i] != '"'; i++)
+                    {
+                        if (content[i] == '\\')
+                        {
+                            i++;
+                        }
+                    }
+                }
+                else if (c == '#' || (c == '/' && next == '/'))
+                {
+                    while (i < content.Length && content[i] != '\n')
+                    {
+                        i++;
+                    }
+                    attributes.Append('\n');
+                }
+                else if (c == '/' && next == '*')
+                {
+                    var end = content.IndexOf("*/", i + 2, StringComparison.Ordinal);
+                    i = end < 0 ? content.Length : end + 1;
+                }
+                else if (c == '{')
+                {
+                    depth++;
+                }
+                else if (c == '}')
+                {
+                    depth--;
+                }
+                else if (depth == 1)
+                {
+                    attributes.Append(c);
+                }
+            }
+
+            return attributes.ToString();
+        }
+
+        /// <summary>
+        /// Gets a copy of the parameters with the values of the secure parameters redacted
+        /// </summary>
+        /// <param name="parameters"></param>
+        /// <param name="secureParameters">The names of the secure parameters</param>
+        public static Dictionary<string, object> Redact(IDictionary<string, object> parameters, ISet<string> secureParameters)
+        {
+            return (parameters ?? new Dictionary<string, object>())
+                .ToDictionary(p => p.Key, p => secureParameters.Contains(p.Key) ? RedactedValue : p.Value);
+        }
+
+        /// <summary>
+        /// Creates a salt for <see cref="Hash"/>
+        /// </summary>
+        public static string CreateSalt()
+        {
+            return Convert.ToBase64String(RandomNumberGenerator.GetBytes(16));
+        }
+
+        /// <summary>
+        /// Gets salted hashes of the values of the secure parameters, so a change can be detected without persisting the values
+        /// </summary>
+        /// <param name="parameters"></param>
+        /// <param name="secureParameters">The names of the secure parameters</param>
+        /// <param name="salt">A salt created by <see cref="CreateSalt"/></param>
+        public static Dictionary<string, string> Hash(IDictionary<string, object> parameters, ISet<string> secureParameters, string salt)
+        {
+            var saltBytes = Convert.FromBase64String(salt);
+
+            return (parameters ?? new Dictionary<string, object>())
+                .Where(p => secureParameters.Contains(p.Key))
+                .ToDictionary(p => p.Key, p => Convert.ToBase64String(
+                    Rfc2898DeriveBytes.Pbkdf2(JsonSerializer.Serialize(p.Value), saltBytes, HashIterations, HashAlgorithmName.SHA256, 32)));
+        }
+	}
+}
@@ -0,0 +1,170 @@
+﻿using System.Text.Json;
+
+namespace Modm.Deployments
+{
+    /// <summary>
+    /// Verifies the parameters of a deployment against the parameters section of its ARM template, so invalid
+    /// parameters are reported before the deployment is submitted rather than rejected by ARM later
+    /// </summary>
+	public class TemplateParametersVerifier
+	{
+        static readonly JsonSerializerOptions SerializerOptions = new();
+
+        /// <summary>
+        /// Verifies the parameters of the deployment against the template of the definition
+        /// </summary>
+        /// <param name="definition"></param>
+        /// <param name="parameters">The parameters of the request, since the values of secure parameters are redacted in the definition</param>
+        /// <param name="cancellationToken"></param>
+        /// <returns>The list of validation errors. Empty if the parameters are valid</returns>
+        /// <remarks>
+        /// Terraform templates are not verified since terraform validates variables itself during plan
+        /// </remarks>
+        public virtual async Task<List<string>> VerifyAsync(DeploymentDefinition definition, IDictionary<string, object> parameters, CancellationToken cancellationToken = default)
+        {
+            if (definition?.DeploymentType != DeploymentType.Arm)
+            {
+                return new List<string>();
+            }
+
+            using var stream = File.OpenRead(Path.Combine(definition.WorkingDirectory, definition.MainTemplatePath));
+            using var document = await JsonDocument.ParseAsync(stream, cancellationToken: cancellationToken);
+
+            document.RootElement.TryGetProperty("parameters", out var declarations);
+            return Verify(declarations, parameters);
+        }
+
+        /// <summary>
+        /// Verifies parameters against the parameter declarations of a template
+        /// </summary>
+        /// <param name="declarations">The parameters section of the template</param>
+        /// <param name="parameters">The parameter values</param>
+        internal static List<string> Verify(JsonElement declarations, IDictionary<string, object> parameters)
+        {
+            var errors = new List<string>();
+            var values = new Dictionary<string, JsonElement>(StringComparer.OrdinalIgnoreCase);
+
+            foreach (var parameter in parameters ?? new Dictionary<string, object>())
+            {
+                values[parameter.Key] = JsonSerializer.SerializeToElement(parameter.Value, SerializerOptions);
+            }
+
+            // a template without a parameters section declares none
+            var declared = declarations.ValueKind == JsonValueKind.Object
+                ? declarations.EnumerateObject().ToDictionary(p => p.Name, p => p.Value, StringComparer.OrdinalIgnoreCase)
+                : new Dictionary<string, JsonElement>(StringComparer.OrdinalIgnoreCase);
+
+            foreach (var name in values.Keys.Where(name => !declared.ContainsKey(name)).OrderBy(name => name))
+            {
+                errors.Add($"Parameter '{name}' is not declared in the template.");
+            }
+
+            foreach (var (name, declaration) in declared)
+            {
+                if (!values.TryGetValue(name, out var value) || value.ValueKind == JsonValueKind.Null)
+                {
+                    if (!declaration.TryGetProperty("defaultValue", out _))
+                    {
+                        errors.Add($"Parameter '{name}' is required.");
+                    }
+                    continue;
+                }
+
+                // the value of a referenced secret isn't known until the parameters file is written
+                if (KeyVaultReferenceResolver.IsReference(value))
+                {
+                    continue;
+                }
+
+                var type = declaration.TryGetProperty("type", out var typeElement) ? typeElement.GetString() : null;
+
+                if (!IsOfType(value, type))
+                {
+                    errors.Add($"Parameter '{name}' must be of type {type}.");
+                    continue;
+                }
+
+                errors.AddRange(VerifyConstraints(name, declaration, value));
+            }
+
+            return errors;
+        }
+
+        private static bool IsOfType(JsonElement value, string type)
+        {
+            return type?.ToLowerInvariant() switch
+            {
+                "string" or "securestring" => value.ValueKind == JsonValueKind.String,
+                "int" => value.ValueKind == JsonValueKind.Number && value.TryGetInt64(out _),
+                "bool" => value.ValueKind == JsonValueKind.True || value.ValueKind == JsonValueKind.False,
+                "object" or "secureobject" => value.ValueKind == JsonValueKind.Object,
+                "array" => value.ValueKind == JsonValueKind.Array,
+                _ => true
+            };
+        }
+
+        private static IEnumerable<string> VerifyConstraints(string name, JsonElement declaration, JsonElement value)
+        {
+            if (declaration.TryGetProperty("allowedValues", out var allowedValues) && allowedValues.ValueKind == JsonValueKind.Array)
+            {
+                var candidates = value.ValueKind == JsonValueKind.Array ? value.EnumerateArray().ToList() : new List<JsonElement> { value };
+
+                if (!candidates.All(candidate => allowedValues.EnumerateArray().Any(allowed => AreEqual(allowed, candidate))))
+                {
+                    yield return $"Parameter '{name}' must be one of: {string.Join(", ", allowedValues.EnumerateArray().Select(v => v.ToString()))}.";
+                }
+            }
+
+            var length = value.ValueKind switch
+            {
+                JsonValueKind.String => value.GetString().Length,
+                JsonValueKind.Array => value.GetArrayLength(),
+                _ => (int?)null
+            };
+
+            if (length.HasValue && TryGetNumber(declaration, "minLength", out var minLength) && length < minLength)
+            {
+                yield return $"Parameter '{name}' must have a length of at least {minLength}.";
+            }
+
+            if (length.HasValue && TryGetNumber(declaration, "maxLength", out var maxLength) && length > maxLength)
+            {
+                yield return $"Parameter '{name}' must have a length of at most {maxLength}.";
+            }
+
+            if (value.ValueKind == JsonValueKind.Number && value.TryGetInt64(out var number))
+            {
+                if (TryGetNumber(declaration, "minValue", out var minValue) && number < minValue)
+                {
+                    yield return $"Parameter '{name}' must be at least {minValue}.";
+                }
+
+                if (TryGetNumber(declaration, "maxValue", out var maxValue) && number > maxValue)
+                {
+                    yield return $"Parameter '{name}' must be at most {maxValue}.";
+                }
+            }
+        }
+
+        private static bool TryGetNumber(JsonElement declaration, string propertyName, out long number)
+        {
+            number = 0;
+            return declaration.TryGetProperty(propertyName, out var element) &&
+                element.ValueKind == JsonValueKind.Number &&
+                element.TryGetInt64(out number);
+        }
+
+        /// <summary>
+        /// ARM compares allowed string values case-insensitively
+        /// </summary>
+        private static bool AreEqual(JsonElement allowed, JsonElement value)
+        {
+            if (allowed.ValueKind == JsonValueKind.String && value.ValueKind == JsonValueKind.String)
+            {
+                return string.Equals(allowed.GetString(), value.GetString(), StringComparison.OrdinalIgnoreCase);
+            }
+
+            return allowed.GetRawText() == value.GetRawText();
+        }
+	}
+}
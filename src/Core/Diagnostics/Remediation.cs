@@ -0,0 +1,19 @@
+﻿using System;
+namespace Modm.Diagnostics
+{
+    /// <summary>
+    /// A suggested remediation step for a failed deployment
+    /// </summary>
+	public record Remediation
+	{
+		/// <summary>
+		/// The failure category the remediation addresses, e.g. MissingSubscriptionRegistration
+		/// </summary>
+		public string Category { get; init; }
+
+		/// <summary>
+		/// Human readable description of the step to take
+		/// </summary>
+		public string Description { get; init; }
+	}
+}
@@ -0,0 +1,65 @@
+﻿namespace Modm.Diagnostics
+{
+    /// <summary>
+    /// Rules engine that evaluates the output of a failed deployment and suggests remediation steps
+    /// </summary>
+	public class RemediationEngine
+	{
+        /// <summary>
+        /// The default set of rules, keyed off of the Azure Resource Manager error codes and messages
+        /// which are surfaced by both the ARM and Terraform deployments
+        /// </summary>
+        public static readonly IReadOnlyList<RemediationRule> DefaultRules = new List<RemediationRule>
+        {
+            new("MissingSubscriptionRegistration",
+                @"not registered to use namespace '(?<namespace>[\w\.]+)'",
+                "Register the resource provider '{namespace}' on the subscription, e.g. az provider register --namespace {namespace}"),
+            new("QuotaExceeded",
+                @"\bQuotaExceeded\b|exceeding approved .+? quota",
+                "Request a quota increase for the subscription in the target region, or choose a smaller size."),
+            new("AuthorizationFailed",
+                @"does not have authorization to perform action '(?<action>[^']+)'",
+                "Grant the deploying identity a role that includes the '{action}' permission on the target scope."),
+            new("SkuNotAvailable",
+                @"\bSkuNotAvailable\b",
+                "The requested SKU is not available in the target region. Choose a different SKU or region."),
+            new("RequestDisallowedByPolicy",
+                @"\bRequestDisallowedByPolicy\b",
+                "An Azure Policy assignment denied the request. Review the policy assignments on the target scope or request an exemption."),
+            new("ResourceGroupNotFound",
+                @"Resource group '(?<name>[^']+)' could not be found",
+                "Create the resource group '{name}' or provide an existing resource group.")
+        };
+
+        private readonly IEnumerable<RemediationRule> rules;
+
+        public RemediationEngine() : this(DefaultRules)
+		{
+		}
+
+        public RemediationEngine(IEnumerable<RemediationRule> rules)
+        {
+            this.rules = rules;
+        }
+
+        /// <summary>
+        /// Evaluates all rules against the deployment output
+        /// </summary>
+        /// <param name="logs">The deployment engine output</param>
+        /// <returns>The remediations, one per matched failure category</returns>
+        public IEnumerable<Remediation> Evaluate(string logs)
+        {
+            var remediations = new List<Remediation>();
+
+            foreach (var rule in rules)
+            {
+                if (rule.TryEvaluate(logs, out var remediation))
+                {
+                    remediations.Add(remediation);
+                }
+            }
+
+            return remediations;
+        }
+	}
+}
@@ -0,0 +1,60 @@
+﻿using System.Text.RegularExpressions;
+
+namespace Modm.Diagnostics
+{
+    /// <summary>
+    /// Maps a failure category, identified by a pattern in the deployment engine output, to a remediation
+    /// </summary>
+    /// <remarks>
+    /// Named groups in the pattern can be referenced in the description using {groupName}
+    /// </remarks>
+	public class RemediationRule
+	{
+        private readonly Regex pattern;
+        private readonly string description;
+
+        public string Category { get; }
+
+        public RemediationRule(string category, string pattern, string description)
+		{
+            this.Category = category;
+            this.pattern = new Regex(pattern, RegexOptions.IgnoreCase | RegexOptions.Compiled);
+            this.description = description;
+		}
+
+        /// <summary>
+        /// Evaluates the rule against the output of a deployment
+        /// </summary>
+        /// <param name="logs">The deployment engine output</param>
+        /// <param name="remediation">the remediation if the rule matched</param>
+        /// <returns>Whether the rule matched</returns>
+        public bool TryEvaluate(string logs, out Remediation remediation)
+        {
+            remediation = null;
+
+            if (string.IsNullOrEmpty(logs))
+            {
+                return false;
+            }
+
+            var match = pattern.Match(logs);
+
+            if (!match.Success)
+            {
+                return false;
+            }
+
+            var text = pattern.GetGroupNames()
+                .Where(name => !int.TryParse(name, out _))
+                .Aggregate(description, (current, name) => current.Replace($"{{{name}}}", match.Groups[name].Value));
+
+            remediation = new Remediation
+            {
+                Category = Category,
+                Description = text
+            };
+
+            return true;
+        }
+	}
+}
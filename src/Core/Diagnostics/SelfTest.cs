@@ -0,0 +1,140 @@
+﻿using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
+using Modm.Azure;
+using Modm.Engine;
+using Modm.Jenkins;
+
+namespace Modm.Diagnostics
+{
+    /// <summary>
+    /// Verifies the installer's configuration and its access to the managed identity, Azure Resource Manager and Jenkins,
+    /// reporting each check as passed or failed to help debug a first-time install
+    /// </summary>
+	public class SelfTest
+	{
+        private readonly JenkinsOptions jenkinsOptions;
+        private readonly IManagedIdentityService managedIdentityService;
+        private readonly CredentialHealthService credentialHealthService;
+        private readonly JenkinsReadinessService readinessService;
+        private readonly ILogger<SelfTest> logger;
+
+        public SelfTest(
+            IOptions<JenkinsOptions> jenkinsOptions,
+            IManagedIdentityService managedIdentityService,
+            CredentialHealthService credentialHealthService,
+            JenkinsReadinessService readinessService,
+            ILogger<SelfTest> logger)
+		{
+            this.jenkinsOptions = jenkinsOptions.Value;
+            this.managedIdentityService = managedIdentityService;
+            this.credentialHealthService = credentialHealthService;
+            this.readinessService = readinessService;
+            this.logger = logger;
+		}
+
+        public async Task<SelfTestReport> RunAsync(CancellationToken cancellationToken = default)
+        {
+            var report = new SelfTestReport();
+
+            report.Checks.Add(CheckConfiguration());
+            report.Checks.Add(await CheckAsync("managedIdentity", CheckManagedIdentityAsync, cancellationToken));
+            report.Checks.Add(await CheckAsync("azureResourceManager", CheckAzureResourceManagerAsync, cancellationToken));
+            report.Checks.Add(await CheckAsync("jenkins", CheckJenkinsAsync, cancellationToken));
+
+            foreach (var check in report.Checks.Where(c => !c.Passed))
+            {
+                logger.LogWarning("Self test check {name} failed. {message}", check.Name, check.Message);
+            }
+
+            return report;
+        }
+
+        private SelfTestCheck CheckConfiguration()
+        {
+            var settings = new Dictionary<string, string>
+            {
+                { nameof(JenkinsOptions.BaseUrl), jenkinsOptions?.BaseUrl },
+                { nameof(JenkinsOptions.UserName), jenkinsOptions?.UserName },
+                { nameof(JenkinsOptions.Password), jenkinsOptions?.Password }
+            };
+
+            var missing = settings
+                .Where(s => string.IsNullOrEmpty(s.Value))
+                .Select(s => $"{JenkinsOptions.ConfigSectionKey}:{s.Key}")
+                .ToList();
+
+            return new SelfTestCheck
+            {
+                Name = "configuration",
+                Passed = missing.Count == 0,
+                Message = missing.Count == 0 ? "The required settings are configured." : $"Missing settings: {string.Join(", ", missing)}"
+            };
+        }
+
+        private async Task<SelfTestCheck> CheckManagedIdentityAsync(CancellationToken cancellationToken)
+        {
+            var accessible = await managedIdentityService.IsAccessibleAsync(cancellationToken);
+
+            return new SelfTestCheck
+            {
+                Name = "managedIdentity",
+                Passed = accessible,
+                Message = accessible ? "A token can be acquired for the managed identity." : "No managed identity is assigned to the installer or the instance metadata service is unreachable."
+            };
+        }
+
+        private async Task<SelfTestCheck> CheckAzureResourceManagerAsync(CancellationToken cancellationToken)
+        {
+            var status = await credentialHealthService.CheckAsync(cancellationToken);
+
+            return new SelfTestCheck
+            {
+                Name = "azureResourceManager",
+                Passed = status.IsHealthy,
+                Message = status.Message
+            };
+        }
+
+        private async Task<SelfTestCheck> CheckJenkinsAsync(CancellationToken cancellationToken)
+        {
+            var info = await readinessService.CheckAsync(cancellationToken);
+
+            return new SelfTestCheck
+            {
+                Name = "jenkins",
+                Passed = info.IsHealthy,
+                Message = info.IsHealthy ? "Jenkins is ready." : $"Jenkins is not ready. {Redact(info.Message)}"
+            };
+        }
+
+        /// <summary>
+        /// Masks the details of jenkins, the same way the diagnostics of the deployment engine are
+        /// </summary>
+        private string Redact(string message)
+        {
+            if (string.IsNullOrEmpty(message))
+            {
+                return message;
+            }
+
+            if (!string.IsNullOrEmpty(jenkinsOptions?.BaseUrl))
+            {
+                message = message.Replace(jenkinsOptions.BaseUrl, "***", StringComparison.OrdinalIgnoreCase);
+            }
+
+            return message.Replace("jenkins", "***", StringComparison.OrdinalIgnoreCase);
+        }
+
+        private async Task<SelfTestCheck> CheckAsync(string name, Func<CancellationToken, Task<SelfTestCheck>> check, CancellationToken cancellationToken)
+        {
+            try
+            {
+                return await check(cancellationToken);
+            }
+            catch (Exception ex) when (ex is not OperationCanceledException)
+            {
+                return new SelfTestCheck { Name = name, Passed = false, Message = Redact(ex.Message) };
+            }
+        }
+	}
+}
@@ -0,0 +1,18 @@
+﻿using System;
+namespace Modm.Diagnostics
+{
+    /// <summary>
+    /// The result of a single check of the <see cref="SelfTest"/>
+    /// </summary>
+	public record SelfTestCheck
+	{
+		public string Name { get; init; }
+
+		public bool Passed { get; init; }
+
+		/// <summary>
+		/// What was verified, or why the check failed
+		/// </summary>
+		public string Message { get; init; }
+	}
+}
@@ -0,0 +1,23 @@
+﻿using System;
+namespace Modm.Diagnostics
+{
+	public record SelfTestReport
+	{
+		public bool Passed => Checks.All(c => c.Passed);
+
+		public List<SelfTestCheck> Checks { get; init; } = new();
+
+		/// <summary>
+		/// Gets the report as lines of text, one per check followed by the overall result
+		/// </summary>
+		public IEnumerable<string> GetLines()
+		{
+			foreach (var check in Checks)
+			{
+				yield return $"[{(check.Passed ? "PASS" : "FAIL")}] {check.Name}: {check.Message}";
+			}
+
+			yield return Passed ? "All checks passed." : "One or more checks failed.";
+		}
+	}
+}
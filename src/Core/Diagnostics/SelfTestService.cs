@@ -0,0 +1,37 @@
+﻿using Microsoft.Extensions.Hosting;
+using Microsoft.Extensions.Logging;
+
+namespace Modm.Diagnostics
+{
+    /// <summary>
+    /// Runs the <see cref="SelfTest"/> once at startup and logs its report
+    /// </summary>
+    /// <remarks>
+    /// Delayed so Jenkins, which is started along with the installer, has a chance to become ready
+    /// </remarks>
+	public class SelfTestService : BackgroundService
+	{
+        const int DefaultStartupDelaySeconds = 60;
+
+        private readonly SelfTest selfTest;
+        private readonly ILogger<SelfTestService> logger;
+
+        public SelfTestService(SelfTest selfTest, ILogger<SelfTestService> logger)
+		{
+            this.selfTest = selfTest;
+            this.logger = logger;
+		}
+
+        protected override async Task ExecuteAsync(CancellationToken stoppingToken)
+        {
+            await Task.Delay(DefaultStartupDelaySeconds * 1000, stoppingToken);
+
+            var report = await selfTest.RunAsync(stoppingToken);
+
+            foreach (var line in report.GetLines())
+            {
+                logger.LogInformation("Self test: {line}", line);
+            }
+        }
+    }
+}
@@ -1,6 +1,7 @@
 ﻿using System;
 using System.Net.NetworkInformation;
 using System.Text.Json.Serialization;
+using Modm.Azure;
 
 namespace Modm.Engine
 {
@@ -18,6 +19,9 @@ namespace Modm.Engine
         [JsonPropertyName("version")]
 		public required string Version { get; set; }
 
+        [JsonPropertyName("credential")]
+        public CredentialStatus Credential { get; set; }
+
 		public static EngineInfo Default()
         {
 			return new EngineInfo { IsHealthy = false, Version = "Unknown", Message = string.Empty };
@@ -2,6 +2,7 @@
 using Microsoft.Azure.Management.ResourceManager.Fluent.Core.DAG;
 using Modm.Azure;
 using Modm.Deployments;
+using Modm.Diagnostics;
 using Modm.Jenkins.Client;
 using Modm.Engine.Pipelines;
 using Microsoft.Extensions.Logging;
@@ -17,6 +18,8 @@ namespace Modm.Engine
         private readonly IMetadataService metadataService;
         private readonly ILogger<JenkinsDeploymentEngine> logger;
         private readonly JenkinsReadinessService readinessService;
+        private readonly RemediationEngine remediationEngine;
+        private readonly CredentialHealthService credentialHealthService;
 
         public JenkinsDeploymentEngine(DeploymentFile file,
             JenkinsClientFactory clientFactory,
@@ -25,6 +28,8 @@ namespace Modm.Engine
             StartDeploymentResult> pipeline,
             IMetadataService metadataService,
             JenkinsReadinessService readinessService,
+            RemediationEngine remediationEngine,
+            CredentialHealthService credentialHealthService,
             ILogger<JenkinsDeploymentEngine> logger)
         {
             this.file = file;
@@ -33,13 +38,26 @@ namespace Modm.Engine
             this.pipeline = pipeline;
             this.metadataService = metadataService;
             this.readinessService = readinessService;
+            this.remediationEngine = remediationEngine;
+            this.credentialHealthService = credentialHealthService;
             this.logger = logger;
         }
 
         public Task<EngineInfo> GetInfo()
         {
             this.logger.LogTrace("Inside JenkinsDeploymentEngine:GetInfo()");
-            return Task.FromResult(this.readinessService.GetEngineInfo());
+
+            var credential = this.credentialHealthService.GetStatus();
+            var info = this.readinessService.GetEngineInfo() with { Credential = credential };
+
+            // the engine can't deploy anything without a working credential
+            if (!credential.IsHealthy)
+            {
+                info.IsHealthy = false;
+                info.Message = string.IsNullOrEmpty(info.Message) ? credential.Message : $"{info.Message}. {credential.Message}";
+            }
+
+            return Task.FromResult(info);
         }
 
         public async Task<string> GetLogs()
@@ -62,6 +80,11 @@ namespace Modm.Engine
             deployment.OfferName = compute.Offer;
             deployment.Resources = await deploymentResourcesClient.Get(compute.ResourceGroupName);
 
+            if (deployment.Status == DeploymentStatus.Failure)
+            {
+                deployment.Remediations = remediationEngine.Evaluate(await GetLogs());
+            }
+
             return deployment;
         }
 
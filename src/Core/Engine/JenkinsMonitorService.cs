@@ -4,6 +4,7 @@ using Microsoft.Extensions.Logging;
 using Modm.Jenkins.Client;
 using Modm.Engine.Notifications;
 using Modm.Deployments;
+using Modm.Diagnostics;
 
 namespace Modm.Engine
 {
@@ -15,6 +16,7 @@ namespace Modm.Engine
         private JenkinsClientFactory clientFactory;
         private DeploymentFile deploymentFile;
         private AuditFile auditFile;
+        private readonly RemediationEngine remediationEngine;
         private readonly ILogger<JenkinsMonitorService> logger;
 
         private bool deploymentStarted;
@@ -25,11 +27,13 @@ namespace Modm.Engine
             JenkinsClientFactory clientFactory,
             DeploymentFile deploymentFile,
             AuditFile auditFile,
+            RemediationEngine remediationEngine,
             ILogger<JenkinsMonitorService> logger)
         {
             this.clientFactory = clientFactory;
             this.deploymentFile = deploymentFile;
             this.auditFile = auditFile;
+            this.remediationEngine = remediationEngine;
             this.logger = logger;
         }
 
@@ -87,24 +91,110 @@ namespace Modm.Engine
                 await Task.Delay(1000, cancellationToken);
             }
 
+            var finalStatus = await client.GetBuildStatus(name, id);
+            IDictionary<string, DeploymentOutput> outputs = null;
+            var violations = new List<string>();
+
+            // verified before the final status is written, so a deployment that drifted from its outputs is never seen as completed
+            if (finalStatus == DeploymentStatus.Completed)
+            {
+                (outputs, violations) = await CaptureOutputs(cancellationToken);
+
+                if (violations.Count > 0)
+                {
+                    finalStatus = DeploymentStatus.Failure;
+                }
+            }
+
+            if (!currentStatus.Equals(finalStatus) || outputs != null)
+            {
+                await UpdateDeploymentStatus(id, finalStatus, cancellationToken, outputs);
+            }
+
+            if (violations.Count > 0)
+            {
+                await AuditOutputContractViolations(violations, cancellationToken);
+            }
+            else if (finalStatus == DeploymentStatus.Failure)
+            {
+                await AuditRemediations(client, cancellationToken);
+            }
+
             // deployment is complete
             logger.LogInformation("Deployment [{id}] completed at: {time}", id, DateTimeOffset.Now);
             Reset();
         }
 
-        private async Task UpdateDeploymentStatus(int deploymentId, string status, CancellationToken token)
+        private async Task UpdateDeploymentStatus(int deploymentId, string status, CancellationToken token, IDictionary<string, DeploymentOutput> outputs = null)
         {
             Deployment deployment = await this.deploymentFile.ReadAsync(token);
             deployment.Id = id;
             deployment.Status = status;
-            await this.deploymentFile.WriteAsync(deployment, token);
 
-            var auditRecords = await this.auditFile.ReadAsync(token);
+            if (outputs != null)
+            {
+                deployment.Outputs = outputs;
+            }
+            await this.deploymentFile.WriteAsync(deployment, token);
 
             AuditRecord newStatusAudit = new AuditRecord();
             newStatusAudit.AdditionalData.Add("statusChange", deployment);
-            auditRecords.Add(newStatusAudit);
-            await this.auditFile.WriteAsync(auditRecords, token);
+            await this.auditFile.AppendAsync(newStatusAudit, token);
+        }
+
+        /// <summary>
+        /// Reads the outputs of the completed deployment and verifies them against the outputs declared in the manifest
+        /// </summary>
+        /// <returns>The outputs, null if they couldn't be read, and the output contract violations</returns>
+        private async Task<(IDictionary<string, DeploymentOutput> Outputs, List<string> Violations)> CaptureOutputs(CancellationToken token)
+        {
+            Deployment deployment = await this.deploymentFile.ReadAsync(token);
+            var definition = deployment?.Definition;
+
+            try
+            {
+                var outputs = await DeploymentOutput.ReadAsync(definition?.WorkingDirectory, token);
+                logger.LogInformation("Deployment [{id}] completed with {count} outputs", id, outputs.Count);
+
+                return (outputs, OutputContractVerifier.Verify(definition?.ExpectedOutputs, outputs));
+            }
+            catch (Exception ex) when (ex is not OperationCanceledException)
+            {
+                logger.LogError(ex, "Failed to capture the outputs of deployment [{id}]", id);
+
+                // the contract can't be verified, so a deployment that declares outputs doesn't complete
+                var violations = definition?.ExpectedOutputs?.Count > 0
+                    ? new List<string> { "The outputs of the deployment could not be read" }
+                    : new List<string>();
+
+                return (null, violations);
+            }
+        }
+
+        private async Task AuditOutputContractViolations(List<string> violations, CancellationToken token)
+        {
+            logger.LogWarning("Deployment [{id}] failed with {count} output contract violations", id, violations.Count);
+
+            AuditRecord violationAudit = new AuditRecord();
+            violationAudit.AdditionalData.Add("outputContractViolation", violations);
+            await this.auditFile.AppendAsync(violationAudit, token);
+        }
+
+        private async Task AuditRemediations(IJenkinsClient client, CancellationToken token)
+        {
+            var logs = await client.GetBuildLogs(name, id);
+            var remediations = remediationEngine.Evaluate(logs).ToList();
+
+            if (remediations.Count == 0)
+            {
+                return;
+            }
+
+            logger.LogInformation("Deployment [{id}] failed with {count} suggested remediations", id, remediations.Count);
+
+            AuditRecord remediationAudit = new AuditRecord();
+            remediationAudit.AdditionalData.Add("remediations", remediations);
+            await this.auditFile.AppendAsync(remediationAudit, token);
         }
 
         void Reset()
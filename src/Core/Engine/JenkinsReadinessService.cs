@@ -116,6 +116,27 @@ namespace Modm.Engine
             return result;
         }
 
+        /// <summary>
+        /// Checks once, without retries, whether jenkins is ready
+        /// </summary>
+        public async Task<EngineInfo> CheckAsync(CancellationToken cancellationToken = default)
+        {
+            var response = await httpClient.GetAsync($"{this.jenkinsOptions.BaseUrl}/login", cancellationToken);
+
+            if (!response.IsSuccessStatusCode)
+            {
+                var result = EngineInfo.Default();
+                result.Message = $"The login page returned {(int)response.StatusCode}.";
+
+                return result;
+            }
+
+            var engineInfo = await GetEngineInfoAsync();
+            UpdateEngineInfo(engineInfo);
+
+            return engineInfo;
+        }
+
         public EngineInfo GetEngineInfo()
         {
             this.logger.LogInformation($"Returning from GetEngineInfo() - {this.engineInfo}");
@@ -1,10 +1,13 @@
 ﻿using FluentValidation;
 using MediatR;
 using MediatR.Pipeline;
+using FluentValidation.Results;
 using Microsoft.Extensions.DependencyInjection;
 using Modm.Packaging;
 using Modm.Deployments;
 using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
+using Modm.Azure;
 
 namespace Modm.Engine.Pipelines
 {
@@ -19,6 +22,7 @@ namespace Modm.Engine.Pipelines
             // since we're going to handle the build up of the definition
    
             c.AddBehavior<CreateParametersFile>();
+            c.AddBehavior<ScanParametersForSecrets>();
             c.AddBehavior<ReadManifestFile>();
             c.AddBehavior<DownloadAndExtractInstallerPackage>();
             c.AddRequestPostProcessor<WriteToDisk>();
@@ -109,19 +113,70 @@ namespace Modm.Engine.Pipelines
 
             definition.MainTemplatePath = manifest.MainTemplate;
             definition.DeploymentType = manifest.DeploymentType;
+            definition.Prerequisites = manifest.Prerequisites ?? new List<Prerequisite>();
+            definition.ExpectedOutputs = manifest.ExpectedOutputs ?? new List<ExpectedOutput>();
+            definition.PartnerId = manifest.PartnerId;
+
+            var mainTemplatePath = Path.Combine(definition.WorkingDirectory, definition.MainTemplatePath);
+            request.SecureParameterNames = await SecureParameters.GetNamesAsync(definition.DeploymentType, mainTemplatePath, cancellationToken);
 
             return definition;
         }
     }
 
     // #3
+    public class ScanParametersForSecrets : IPipelineBehavior<CreateDeploymentDefinition, DeploymentDefinition>
+    {
+        private readonly SecretScanningOptions options;
+        private readonly ILogger<ScanParametersForSecrets> logger;
+
+        public ScanParametersForSecrets(IOptions<SecretScanningOptions> options, ILogger<ScanParametersForSecrets> logger)
+        {
+            this.options = options.Value;
+            this.logger = logger;
+        }
+
+        public async Task<DeploymentDefinition> Handle(CreateDeploymentDefinition request, RequestHandlerDelegate<DeploymentDefinition> next, CancellationToken cancellationToken)
+        {
+            var definition = await next();
+
+            if (options.Mode == SecretScanningMode.Off)
+            {
+                return definition;
+            }
+
+            var findings = ParameterSecretScanner.Scan(request.Parameters, request.SecureParameterNames);
+
+            if (findings.Count == 0)
+            {
+                return definition;
+            }
+
+            // block before the parameters are written to the parameters file or the deployment file
+            if (options.Mode == SecretScanningMode.Block)
+            {
+                throw new ValidationException("Parameters look like they contain secrets", findings.Select(f => new ValidationFailure("Parameters", f)));
+            }
+
+            logger.LogWarning("Deployment has {count} parameters that look like they contain secrets", findings.Count);
+
+            // audited by WriteToDisk, which starts the audit file of the deployment
+            request.ParameterSecretWarnings.AddRange(findings);
+
+            return definition;
+        }
+    }
+
+    // #4
     public class CreateParametersFile : IPipelineBehavior<CreateDeploymentDefinition, DeploymentDefinition>
     {
         private readonly ParametersFileFactory factory;
+        private readonly KeyVaultReferenceResolver resolver;
 
-        public CreateParametersFile(ParametersFileFactory parametersFileFactory)
+        public CreateParametersFile(ParametersFileFactory parametersFileFactory, KeyVaultReferenceResolver resolver)
         {
             this.factory = parametersFileFactory;
+            this.resolver = resolver;
         }
 
         public async Task<DeploymentDefinition> Handle(CreateDeploymentDefinition request, RequestHandlerDelegate<DeploymentDefinition> next, CancellationToken cancellationToken)
@@ -129,25 +184,36 @@ namespace Modm.Engine.Pipelines
             var definition = await next();
             var file = factory.Create(definition.DeploymentType, definition.GetMainTemplateDirectoryName());
 
-            // the file must always have at least an empty object
-            await file.Write(request.Parameters ?? new Dictionary<string, object>());
+            // the file must always have at least an empty object. only the file receives the values of referenced secrets
+            var parameters = await resolver.ResolveAsync(request.Parameters, cancellationToken);
+            await file.Write(parameters);
             definition.ParametersFilePath = file.FullPath;
 
+            // hashed by WriteToDisk to tell whether a secure value changed since the previous attempt
+            request.SecureParameterValues = parameters
+                .Where(p => request.SecureParameterNames.Contains(p.Key))
+                .ToDictionary(p => p.Key, p => p.Value);
+
+            // the definition is persisted to the deployment file, audited and returned by the api, so it never holds secure values
+            definition.Parameters = SecureParameters.Redact(request.Parameters, request.SecureParameterNames);
+
             return definition;
         }
     }
 
-    // #4
+    // #5
     public class WriteToDisk : IRequestPostProcessor<CreateDeploymentDefinition, DeploymentDefinition>
     {
         private readonly DeploymentFile deploymentFile;
         private readonly AuditFile auditFile;
+        private readonly PartnerAttribution attribution;
         private ILogger<WriteToDisk> logger;
 
-        public WriteToDisk(DeploymentFile deploymentFile, AuditFile auditFile, ILogger<WriteToDisk> logger)
+        public WriteToDisk(DeploymentFile deploymentFile, AuditFile auditFile, PartnerAttribution attribution, ILogger<WriteToDisk> logger)
         {
             this.deploymentFile = deploymentFile;
             this.auditFile = auditFile;
+            this.attribution = attribution;
             this.logger = logger;
         }
 
@@ -155,20 +221,46 @@ namespace Modm.Engine.Pipelines
         {
             this.logger.LogInformation("Inside WriteToDisk of CreateDeploymentPipeline");
 
+            // a previous attempt exists when the deployment is being retried
+            var previous = await deploymentFile.ReadAsync(cancellationToken);
+
+            // the salt is kept across attempts so equal secure values have equal hashes
+            response.SecureParameterSalt = previous?.Definition?.SecureParameterSalt ?? SecureParameters.CreateSalt();
+            response.SecureParameterHashes = SecureParameters.Hash(request.SecureParameterValues, request.SecureParameterNames, response.SecureParameterSalt);
+
+            var parameterChanges = previous?.Definition == null
+                ? new List<ParameterChange>()
+                : ParameterChange.Compare(previous.Definition.Parameters, response.Parameters, request.SecureParameterNames,
+                    previous.Definition.SecureParameterHashes, response.SecureParameterHashes);
+
             var deployment = new Deployment
             {
                 Definition = response,
                 Id = 0,
                 Timestamp = DateTimeOffset.UtcNow,
-                Status = DeploymentStatus.Undefined
+                Status = DeploymentStatus.Undefined,
+                ParameterChanges = parameterChanges
             };
 
             await deploymentFile.WriteAsync(deployment, cancellationToken);
             this.logger.LogInformation("Wrote Deployment to deployment file");
 
+            // the engine's requests for the deployment are attributed like those of the deployment job
+            attribution.Set(response.PartnerId);
+
             var auditRecord = new AuditRecord();
             auditRecord.AdditionalData.Add("createDeploymentPipeline", deployment);
 
+            if (parameterChanges.Count > 0)
+            {
+                auditRecord.AdditionalData.Add("parameterChanges", parameterChanges);
+            }
+
+            if (request.ParameterSecretWarnings.Count > 0)
+            {
+                auditRecord.AdditionalData.Add("parameterSecretWarnings", request.ParameterSecretWarnings);
+            }
+
             await this.auditFile.WriteAsync(new List<AuditRecord>() { auditRecord }, cancellationToken);
         } 
     }
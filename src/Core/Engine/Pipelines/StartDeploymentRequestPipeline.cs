@@ -31,6 +31,9 @@ namespace Modm.Engine.Pipelines
 
             c.AddRequestPostProcessor<WriteDeploymentToDisk>();
             c.AddBehavior<SubmitDeployment>();
+            c.AddBehavior<VerifyRegionAvailability>();
+            c.AddBehavior<VerifyPrerequisites>();
+            c.AddBehavior<VerifyTemplateParameters>();
             c.AddBehavior<ReadDeploymentFromRepository>();
             return c;
         }
@@ -83,6 +86,115 @@ namespace Modm.Engine.Pipelines
     }
 
     // #2
+    public class VerifyTemplateParameters : IPipelineBehavior<StartDeploymentRequest, StartDeploymentResult>
+    {
+        private readonly TemplateParametersVerifier verifier;
+        private readonly ILogger<VerifyTemplateParameters> logger;
+
+        public VerifyTemplateParameters(TemplateParametersVerifier verifier, ILogger<VerifyTemplateParameters> logger)
+        {
+            this.verifier = verifier;
+            this.logger = logger;
+        }
+
+        public async Task<StartDeploymentResult> Handle(StartDeploymentRequest request, RequestHandlerDelegate<StartDeploymentResult> next, CancellationToken cancellationToken)
+        {
+            var result = await next();
+
+            // a deployment that isn't startable is rejected by SubmitDeployment without the lookups
+            if (result.Deployment?.IsStartable != true)
+            {
+                return result;
+            }
+
+            var errors = await verifier.VerifyAsync(result.Deployment?.Definition, request.Parameters, cancellationToken);
+
+            if (errors.Count > 0)
+            {
+                logger.LogWarning("Deployment has {count} invalid parameters", errors.Count);
+
+                result.Errors ??= new List<string>();
+                result.Errors.AddRange(errors);
+            }
+
+            return result;
+        }
+    }
+
+    // #3
+    public class VerifyPrerequisites : IPipelineBehavior<StartDeploymentRequest, StartDeploymentResult>
+    {
+        private readonly PrerequisitesVerifier verifier;
+        private readonly ILogger<VerifyPrerequisites> logger;
+
+        public VerifyPrerequisites(PrerequisitesVerifier verifier, ILogger<VerifyPrerequisites> logger)
+        {
+            this.verifier = verifier;
+            this.logger = logger;
+        }
+
+        public async Task<StartDeploymentResult> Handle(StartDeploymentRequest request, RequestHandlerDelegate<StartDeploymentResult> next, CancellationToken cancellationToken)
+        {
+            var result = await next();
+
+            // a deployment that isn't startable is rejected by SubmitDeployment without the lookups
+            if (result.Deployment?.IsStartable != true)
+            {
+                return result;
+            }
+
+            var prerequisites = result.Deployment?.Definition?.Prerequisites;
+            var unmet = await verifier.VerifyAsync(prerequisites, cancellationToken);
+
+            if (unmet.Count > 0)
+            {
+                logger.LogWarning("Deployment has {count} unmet prerequisites", unmet.Count);
+
+                result.Errors ??= new List<string>();
+                result.Errors.AddRange(unmet);
+            }
+
+            return result;
+        }
+    }
+
+    // #4
+    public class VerifyRegionAvailability : IPipelineBehavior<StartDeploymentRequest, StartDeploymentResult>
+    {
+        private readonly RegionAvailabilityVerifier verifier;
+        private readonly ILogger<VerifyRegionAvailability> logger;
+
+        public VerifyRegionAvailability(RegionAvailabilityVerifier verifier, ILogger<VerifyRegionAvailability> logger)
+        {
+            this.verifier = verifier;
+            this.logger = logger;
+        }
+
+        public async Task<StartDeploymentResult> Handle(StartDeploymentRequest request, RequestHandlerDelegate<StartDeploymentResult> next, CancellationToken cancellationToken)
+        {
+            var result = await next();
+
+            // a deployment that isn't startable is rejected by SubmitDeployment without the lookups
+            if (result.Deployment?.IsStartable != true)
+            {
+                return result;
+            }
+
+            var unavailable = await verifier.VerifyAsync(result.Deployment?.Definition, cancellationToken);
+
+            if (unavailable.Count > 0)
+            {
+                logger.LogWarning("Deployment has {count} resource types or virtual machine sizes unavailable in the target region", unavailable.Count);
+
+                result.Errors ??= new List<string>();
+                result.Errors.AddRange(unavailable);
+            }
+
+            return result;
+        }
+    }
+
+    // #5
     public class SubmitDeployment : IPipelineBehavior<StartDeploymentRequest, StartDeploymentResult>
     {
         private readonly JenkinsClientFactory clientFactory;
@@ -110,6 +222,13 @@ namespace Modm.Engine.Pipelines
                 return result;
             }
 
+            // errors reported by an earlier validation, so don't submit
+            if (result.Errors.Count > 0)
+            {
+                deployment.Id = -1;
+                return result;
+            }
+
             try
             {
                 if (await TryToSubmit(deployment))
@@ -172,7 +291,7 @@ namespace Modm.Engine.Pipelines
         }
     }
 
-    // #3
+    // #6
     public class WriteDeploymentToDisk : IRequestPostProcessor<StartDeploymentRequest, StartDeploymentResult>
     {
         private readonly DeploymentFile deploymentFile;
@@ -195,11 +314,9 @@ namespace Modm.Engine.Pipelines
             var deployment = await this.deploymentFile.ReadAsync(cancellationToken);
             await deploymentFile.WriteAsync(deployment, cancellationToken);
 
-            var auditRecords = await this.auditFile.ReadAsync(cancellationToken);
             var auditRecord = new AuditRecord();
             auditRecord.AdditionalData.Add("WriteDeploymentToDisk:Process", response.Deployment);
-            auditRecords.Add(auditRecord);
-            await this.auditFile.WriteAsync(auditRecords, cancellationToken);
+            await this.auditFile.AppendAsync(auditRecord, cancellationToken);
         }
     }
 
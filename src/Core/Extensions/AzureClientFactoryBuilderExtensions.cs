@@ -0,0 +1,30 @@
+﻿using Azure.Identity;
+using Microsoft.Extensions.Azure;
+using Microsoft.Extensions.Configuration;
+using Modm.Http;
+
+namespace Modm.Extensions
+{
+	public static class AzureClientFactoryBuilderExtensions
+	{
+        /// <summary>
+        /// Uses the <see cref="DefaultAzureCredential"/> for all Azure clients, with both the clients and the credential
+        /// sending requests according to the <see cref="OutboundHttpOptions"/>
+        /// </summary>
+        /// <param name="builder"></param>
+        /// <param name="configuration"></param>
+        /// <returns></returns>
+		public static AzureClientFactoryBuilder UseDefaultAzureCredential(this AzureClientFactoryBuilder builder, IConfiguration configuration)
+		{
+            var options = configuration.GetSection(OutboundHttpOptions.ConfigSectionKey).Get<OutboundHttpOptions>() ?? new OutboundHttpOptions();
+
+            if (options.IsConfigured)
+            {
+                builder.ConfigureDefaults(clientOptions => clientOptions.Transport = OutboundHttpHandler.CreateTransport(options));
+            }
+
+            builder.UseCredential(OutboundHttpHandler.CreateCredential(options));
+            return builder;
+		}
+	}
+}
@@ -0,0 +1,20 @@
+﻿using Microsoft.AspNetCore.Builder;
+using Modm.Http;
+
+namespace Modm.Extensions
+{
+	public static class IApplicationBuilderExtensions
+	{
+        /// <summary>
+        /// Rejects requests from addresses outside of the configured allowlist, see <see cref="IpAllowlistOptions"/>.
+        /// The client address forwarded by a known proxy is applied first
+        /// </summary>
+        /// <param name="app"></param>
+        /// <returns></returns>
+		public static IApplicationBuilder UseIpAllowlist(this IApplicationBuilder app)
+		{
+            app.UseForwardedHeaders();
+            return app.UseMiddleware<IpAllowlistMiddleware>();
+		}
+	}
+}
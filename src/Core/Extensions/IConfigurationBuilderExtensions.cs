@@ -43,7 +43,7 @@ namespace Modm.Extensions
             services.AddAzureClients(clientBuilder =>
             {
                 clientBuilder.AddArmClient(configuration.GetSection("Azure"));
-                clientBuilder.UseCredential(new DefaultAzureCredential());
+                clientBuilder.UseDefaultAzureCredential(configuration);
             });
 
             if (configuration.IsAppServiceEnvironment())
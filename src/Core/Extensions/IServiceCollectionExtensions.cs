@@ -11,6 +11,13 @@ using Modm.Engine.Pipelines;
 using Modm.Jenkins;
 using Microsoft.AspNetCore.Authentication.JwtBearer;
 using Modm.Security;
+using Modm.Diagnostics;
+using Modm.Compression;
+using Modm.Http;
+using Microsoft.Extensions.Http;
+using Microsoft.Extensions.Options;
+using Microsoft.Extensions.DependencyInjection.Extensions;
+using Microsoft.AspNetCore.Builder;
 
 namespace Modm.Extensions
 {
@@ -61,15 +68,27 @@ namespace Modm.Extensions
             services.AddSingleton<DeploymentFile>();
             services.AddSingleton<AuditFile>();
             services.AddSingleton<IDeploymentRepository, DefaultDeploymentRepository>();
+            services.AddSingleton<PrerequisitesVerifier>();
+            services.AddSingleton<RegionAvailabilityVerifier>();
+            services.AddSingleton<TemplateParametersVerifier>();
+            services.AddSingleton<KeyVaultReferenceResolver>();
+            services.AddSingleton<DirectoryZipper>();
+            services.AddSingleton<DeploymentExporter>();
 
             services.AddSingleton<IDeploymentEngine, JenkinsDeploymentEngine>();
             services.AddSingleton<DeploymentResourcesClient>();
+            services.AddSingleton(new RemediationEngine());
+            services.AddSingleton<SelfTest>();
 
             //configuration
             services.Configure<JenkinsOptions>(configuration.GetSection(JenkinsOptions.ConfigSectionKey));
+            services.Configure<SecretScanningOptions>(configuration.GetSection(SecretScanningOptions.ConfigSectionKey));
 
             services.AddSingletonHostedService<JenkinsMonitorService>();
             services.AddSingletonHostedService<JenkinsReadinessService>();
+            services.AddSingletonHostedService<CredentialHealthService>();
+            services.AddSingletonHostedService<PartnerAttribution>();
+            services.AddSingletonHostedService<SelfTestService>();
 
             services.AddMediatR(c =>
             {
@@ -94,6 +113,9 @@ namespace Modm.Extensions
         /// <returns></returns>
         public static IServiceCollection AddDefaultHttpClient(this IServiceCollection services)
         {
+            services.AddOptions<OutboundHttpOptions>().BindConfiguration(OutboundHttpOptions.ConfigSectionKey);
+            services.AddSingleton<IConfigureOptions<HttpClientFactoryOptions>, ConfigureOutboundHttpClient>();
+
             services.AddHttpClient();
             return services;
         }
@@ -117,6 +139,23 @@ namespace Modm.Extensions
 
             return services;
         }
+
+        /// <summary>
+        /// Adds the options for the IP allowlist and the audit of rejected requests, see <see cref="IApplicationBuilderExtensions.UseIpAllowlist"/>
+        /// </summary>
+        /// <param name="services"></param>
+        /// <param name="configuration"></param>
+        /// <returns></returns>
+        public static IServiceCollection AddIpAllowlist(this IServiceCollection services, IConfiguration configuration)
+        {
+            services.Configure<IpAllowlistOptions>(configuration.GetSection(IpAllowlistOptions.ConfigSectionKey));
+            services.AddOptions<ForwardedHeadersOptions>()
+                .Configure<IOptions<IpAllowlistOptions>>((forwardedHeaders, options) => IpAllowlistMiddleware.ConfigureForwardedHeaders(forwardedHeaders, options.Value));
+
+            services.TryAddSingleton<AuditFile>();
+            services.AddSingletonHostedService<IpAllowlistAudit>();
+            return services;
+        }
     }
 }
 
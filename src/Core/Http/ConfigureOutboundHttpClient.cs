@@ -0,0 +1,36 @@
+﻿using Microsoft.Extensions.Http;
+using Microsoft.Extensions.Options;
+
+namespace Modm.Http
+{
+    /// <summary>
+    /// Applies the <see cref="OutboundHttpOptions"/> to every client created by the http client factory
+    /// </summary>
+	public class ConfigureOutboundHttpClient : IConfigureNamedOptions<HttpClientFactoryOptions>
+	{
+        private readonly OutboundHttpOptions options;
+
+        public ConfigureOutboundHttpClient(IOptions<OutboundHttpOptions> options)
+		{
+            this.options = options.Value;
+		}
+
+        public void Configure(string name, HttpClientFactoryOptions clientOptions)
+        {
+            if (!options.IsConfigured)
+            {
+                return;
+            }
+
+            clientOptions.HttpMessageHandlerBuilderActions.Add(builder =>
+            {
+                builder.PrimaryHandler = OutboundHttpHandler.Create(options);
+            });
+        }
+
+        public void Configure(HttpClientFactoryOptions clientOptions)
+        {
+            Configure(Options.DefaultName, clientOptions);
+        }
+    }
+}
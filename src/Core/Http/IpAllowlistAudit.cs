@@ -0,0 +1,116 @@
+﻿using System.Collections.Concurrent;
+using System.Net;
+using Microsoft.Extensions.Hosting;
+using Microsoft.Extensions.Logging;
+using Modm.Deployments;
+
+namespace Modm.Http
+{
+    /// <summary>
+    /// Aggregates the requests rejected by the <see cref="IpAllowlistMiddleware"/> and periodically writes them to the audit file
+    /// as a single record, so rejected callers can't grow the audit file with every request they make
+    /// </summary>
+	public class IpAllowlistAudit : BackgroundService
+	{
+        const int MaxAddresses = 100;
+        static readonly TimeSpan FlushInterval = TimeSpan.FromMinutes(1);
+
+        private readonly AuditFile auditFile;
+        private readonly ILogger<IpAllowlistAudit> logger;
+
+        private ConcurrentDictionary<string, Rejection> rejections = new();
+        private int droppedCount;
+
+        public IpAllowlistAudit(AuditFile auditFile, ILogger<IpAllowlistAudit> logger)
+		{
+            this.auditFile = auditFile;
+            this.logger = logger;
+		}
+
+        /// <summary>
+        /// Records a rejected request
+        /// </summary>
+        public void Add(IPAddress address, string path)
+        {
+            var key = address?.ToString() ?? "unknown";
+            var now = DateTimeOffset.UtcNow;
+            var current = rejections;
+
+            // the addresses are bounded until the next flush, beyond that only a count is kept
+            if (current.Count >= MaxAddresses && !current.ContainsKey(key))
+            {
+                Interlocked.Increment(ref droppedCount);
+                return;
+            }
+
+            current.AddOrUpdate(key,
+                _ => new Rejection { Address = key, Count = 1, Path = path, FirstRejected = now, LastRejected = now },
+                (_, rejection) => rejection with { Count = rejection.Count + 1, Path = path, LastRejected = now });
+        }
+
+        protected override async Task ExecuteAsync(CancellationToken stoppingToken)
+        {
+            try
+            {
+                while (!stoppingToken.IsCancellationRequested)
+                {
+                    await Task.Delay(FlushInterval, stoppingToken);
+                    await FlushAsync(stoppingToken);
+                }
+            }
+            catch (OperationCanceledException)
+            {
+            }
+        }
+
+        public override async Task StopAsync(CancellationToken cancellationToken)
+        {
+            await base.StopAsync(cancellationToken);
+            await FlushAsync(cancellationToken);
+        }
+
+        /// <summary>
+        /// Writes the rejections since the last flush to the audit file. Nothing is written if there were none
+        /// </summary>
+        internal async Task FlushAsync(CancellationToken cancellationToken = default)
+        {
+            var flushed = Interlocked.Exchange(ref rejections, new ConcurrentDictionary<string, Rejection>());
+            var dropped = Interlocked.Exchange(ref droppedCount, 0);
+
+            if (flushed.IsEmpty && dropped == 0)
+            {
+                return;
+            }
+
+            try
+            {
+                var auditRecord = new AuditRecord();
+                auditRecord.AdditionalData.Add("ipAllowlistRejected", new
+                {
+                    addresses = flushed.Values.OrderBy(r => r.FirstRejected).ToList(),
+                    droppedCount = dropped
+                });
+
+                await auditFile.AppendAsync(auditRecord, cancellationToken);
+            }
+            catch (Exception ex)
+            {
+                logger.LogError(ex, "Failed to write audit record for rejected requests");
+            }
+        }
+
+        public record Rejection
+        {
+            public string Address { get; init; }
+            public int Count { get; init; }
+
+            /// <summary>
+            /// The path of the most recent rejected request
+            /// </summary>
+            public string Path { get; init; }
+
+            public DateTimeOffset FirstRejected { get; init; }
+            public DateTimeOffset LastRejected { get; init; }
+        }
+    }
+}
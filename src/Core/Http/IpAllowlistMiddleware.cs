@@ -0,0 +1,115 @@
+﻿using System.Net;
+using System.Net.Sockets;
+using Microsoft.AspNetCore.Builder;
+using Microsoft.AspNetCore.Http;
+using Microsoft.AspNetCore.HttpOverrides;
+using Microsoft.Extensions.DependencyInjection;
+using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
+using IPNetwork = Microsoft.AspNetCore.HttpOverrides.IPNetwork;
+
+namespace Modm.Http
+{
+    /// <summary>
+    /// Rejects requests that don't originate from the ranges configured in <see cref="IpAllowlistOptions"/>
+    /// </summary>
+    /// <remarks>
+    /// On the installer, requests arrive through the caddy reverse proxy. The forwarded headers middleware must run first,
+    /// see <see cref="ConfigureForwardedHeaders"/>, so the address checked is that of the client rather than the proxy
+    /// </remarks>
+	public class IpAllowlistMiddleware
+	{
+        private readonly RequestDelegate next;
+        private readonly List<IPNetwork> networks;
+        private readonly ILogger<IpAllowlistMiddleware> logger;
+
+        public IpAllowlistMiddleware(RequestDelegate next, IOptions<IpAllowlistOptions> options, ILogger<IpAllowlistMiddleware> logger)
+		{
+            this.next = next;
+            this.networks = (options.Value.AllowedRanges ?? new List<string>()).Select(ParseRange).ToList();
+            this.logger = logger;
+		}
+
+        public async Task InvokeAsync(HttpContext context)
+        {
+            var address = context.Connection.RemoteIpAddress;
+
+            if (IsAllowed(address))
+            {
+                await next(context);
+                return;
+            }
+
+            logger.LogWarning("Rejected request from {address} to {path}, address is not in the allowlist", address, context.Request.Path);
+            context.RequestServices?.GetService<IpAllowlistAudit>()?.Add(address, context.Request.Path.ToString());
+
+            context.Response.StatusCode = StatusCodes.Status403Forbidden;
+        }
+
+        public bool IsAllowed(IPAddress address)
+        {
+            if (networks.Count == 0)
+            {
+                return true;
+            }
+
+            if (address == null)
+            {
+                return false;
+            }
+
+            if (address.IsIPv4MappedToIPv6)
+            {
+                address = address.MapToIPv4();
+            }
+
+            // the client app and the backend api communicate over loopback on the installer
+            if (IPAddress.IsLoopback(address))
+            {
+                return true;
+            }
+
+            return networks.Any(network => network.Contains(address));
+        }
+
+        /// <summary>
+        /// Configures the forwarded headers to only be trusted from the proxies in <see cref="IpAllowlistOptions.KnownProxies"/>
+        /// </summary>
+        /// <param name="forwardedHeaders"></param>
+        /// <param name="options"></param>
+        public static void ConfigureForwardedHeaders(ForwardedHeadersOptions forwardedHeaders, IpAllowlistOptions options)
+        {
+            forwardedHeaders.ForwardedHeaders = ForwardedHeaders.XForwardedFor | ForwardedHeaders.XForwardedProto;
+
+            // the defaults trust loopback, which would let the client app forward any address to the backend api
+            forwardedHeaders.KnownNetworks.Clear();
+            forwardedHeaders.KnownProxies.Clear();
+
+            foreach (var network in (options.KnownProxies ?? new List<string>()).Select(ParseRange))
+            {
+                forwardedHeaders.KnownNetworks.Add(network);
+
+                // kestrel listens dual-stack, so proxies connect with an IPv4-mapped IPv6 address
+                if (network.Prefix.AddressFamily == AddressFamily.InterNetwork)
+                {
+                    forwardedHeaders.KnownNetworks.Add(new IPNetwork(network.Prefix.MapToIPv6(), network.PrefixLength + 96));
+                }
+            }
+        }
+
+        /// <summary>
+        /// Parses a CIDR range, e.g. 10.0.0.0/16. A single address is treated as a range of one address
+        /// </summary>
+        public static IPNetwork ParseRange(string range)
+        {
+            var parts = range.Trim().Split('/');
+            var prefix = IPAddress.Parse(parts[0]);
+
+            var prefixLength = parts.Length > 1
+                ? int.Parse(parts[1])
+                : prefix.AddressFamily == AddressFamily.InterNetworkV6 ? 128 : 32;
+
+            return new IPNetwork(prefix, prefixLength);
+        }
+    }
+}
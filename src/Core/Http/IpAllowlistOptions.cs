@@ -0,0 +1,20 @@
+﻿using System;
+
+namespace Modm.Http
+{
+	public class IpAllowlistOptions
+	{
+        public const string ConfigSectionKey = "IpAllowlist";
+
+        /// <summary>
+        /// The CIDR ranges that requests are allowed from, e.g. 203.0.113.0/24. When empty, requests from any address are allowed
+        /// </summary>
+        public List<string> AllowedRanges { get; set; } = new();
+
+        /// <summary>
+        /// The CIDR ranges of the reverse proxies trusted to forward the client address in X-Forwarded-For, e.g. the caddy container.
+        /// When empty, forwarded headers are ignored
+        /// </summary>
+        public List<string> KnownProxies { get; set; } = new();
+	}
+}
@@ -0,0 +1,126 @@
+﻿using System.Net;
+using System.Net.Security;
+using System.Security.Authentication;
+using System.Security.Cryptography.X509Certificates;
+using Azure.Core;
+using Azure.Core.Pipeline;
+using Azure.Identity;
+
+namespace Modm.Http
+{
+    /// <summary>
+    /// Creates the primary handler for outbound http requests from <see cref="OutboundHttpOptions"/>
+    /// </summary>
+	public static class OutboundHttpHandler
+	{
+        /// <summary>
+        /// the instance metadata service is link-local and can't be reached through a proxy
+        /// </summary>
+        const string InstanceMetadataServiceAddress = @"169\.254\.169\.254";
+
+        public static HttpMessageHandler Create(OutboundHttpOptions options)
+        {
+            var handler = new SocketsHttpHandler();
+
+            if (!string.IsNullOrEmpty(options.ProxyUrl))
+            {
+                var bypassList = (options.ProxyBypassList ?? new List<string>())
+                    .Append(InstanceMetadataServiceAddress)
+                    .ToArray();
+
+                handler.Proxy = new WebProxy(new Uri(options.ProxyUrl), true, bypassList);
+                handler.UseProxy = true;
+            }
+
+            if (!string.IsNullOrEmpty(options.MinimumTlsVersion))
+            {
+                handler.SslOptions.EnabledSslProtocols = GetSslProtocols(options.MinimumTlsVersion);
+            }
+
+            if (options.RootCertificatePaths?.Count > 0)
+            {
+                var roots = new X509Certificate2Collection();
+
+                foreach (var path in options.RootCertificatePaths)
+                {
+                    roots.ImportFromPemFile(path);
+                }
+
+                handler.SslOptions.RemoteCertificateValidationCallback = (sender, certificate, chain, errors) =>
+                    IsTrusted(roots, certificate, chain, errors);
+            }
+
+            return handler;
+        }
+
+        /// <summary>
+        /// Creates the transport for the Azure SDK clients
+        /// </summary>
+        public static HttpPipelineTransport CreateTransport(OutboundHttpOptions options)
+        {
+            return new HttpClientTransport(Create(options));
+        }
+
+        /// <summary>
+        /// Creates the <see cref="DefaultAzureCredential"/>, acquiring tokens through the transport when outbound http is configured
+        /// </summary>
+        public static TokenCredential CreateCredential(OutboundHttpOptions options)
+        {
+            if (options == null || !options.IsConfigured)
+            {
+                return new DefaultAzureCredential();
+            }
+
+            return new DefaultAzureCredential(new DefaultAzureCredentialOptions
+            {
+                Transport = CreateTransport(options)
+            });
+        }
+
+        private static SslProtocols GetSslProtocols(string minimumTlsVersion)
+        {
+            return minimumTlsVersion switch
+            {
+                "1.2" => SslProtocols.Tls12 | SslProtocols.Tls13,
+                "1.3" => SslProtocols.Tls13,
+                _ => throw new ArgumentOutOfRangeException(nameof(minimumTlsVersion), $"TLS version '{minimumTlsVersion}' is not supported, use 1.2 or 1.3.")
+            };
+        }
+
+        /// <summary>
+        /// Trusts the certificate if it's valid against the system's roots, otherwise it must chain to one of the custom roots
+        /// </summary>
+        /// <remarks>
+        /// The chain is rebuilt with the intermediates the server sent, since they can't be downloaded in restricted networks
+        /// </remarks>
+        internal static bool IsTrusted(X509Certificate2Collection roots, X509Certificate certificate, X509Chain chain, SslPolicyErrors errors)
+        {
+            if (errors == SslPolicyErrors.None)
+            {
+                return true;
+            }
+
+            if (errors != SslPolicyErrors.RemoteCertificateChainErrors || certificate == null)
+            {
+                return false;
+            }
+
+            using var customChain = new X509Chain();
+            customChain.ChainPolicy.TrustMode = X509ChainTrustMode.CustomRootTrust;
+            customChain.ChainPolicy.CustomTrustStore.AddRange(roots);
+            customChain.ChainPolicy.RevocationMode = chain?.ChainPolicy.RevocationMode ?? X509RevocationMode.NoCheck;
+
+            if (chain != null)
+            {
+                foreach (var element in chain.ChainElements)
+                {
+                    customChain.ChainPolicy.ExtraStore.Add(element.Certificate);
+                }
+
+                customChain.ChainPolicy.ExtraStore.AddRange(chain.ChainPolicy.ExtraStore);
+            }
+
+            return customChain.Build(new X509Certificate2(certificate));
+        }
+    }
+}
@@ -0,0 +1,36 @@
+﻿using System;
+
+namespace Modm.Http
+{
+    /// <summary>
+    /// Configuration applied to all outbound http clients, including the Azure SDK clients, for egress restricted environments
+    /// </summary>
+	public class OutboundHttpOptions
+	{
+        public const string ConfigSectionKey = "OutboundHttp";
+
+        /// <summary>
+        /// The url of the proxy, e.g. http://proxy.contoso.com:3128
+        /// </summary>
+        public string ProxyUrl { get; set; }
+
+        /// <summary>
+        /// Hosts (regular expressions) that bypass the proxy. Local addresses and the instance metadata service always bypass it
+        /// </summary>
+        public List<string> ProxyBypassList { get; set; } = new();
+
+        /// <summary>
+        /// Paths to PEM encoded root certificates trusted in addition to the system's root certificates
+        /// </summary>
+        public List<string> RootCertificatePaths { get; set; } = new();
+
+        /// <summary>
+        /// The minimum TLS version, either 1.2 or 1.3. Defaults to the system default when not set
+        /// </summary>
+        public string MinimumTlsVersion { get; set; }
+
+        public bool IsConfigured => !string.IsNullOrEmpty(ProxyUrl)
+            || RootCertificatePaths?.Count > 0
+            || !string.IsNullOrEmpty(MinimumTlsVersion);
+	}
+}
@@ -0,0 +1,25 @@
+﻿using System;
+namespace Modm.Packaging
+{
+    /// <summary>
+    /// An output declared in the manifest that the deployment must produce, e.g. the hostname of a web app
+    /// that the offer's documentation or downstream automation relies on
+    /// </summary>
+	public record ExpectedOutput
+	{
+        /// <summary>
+        /// The name of the output in the template
+        /// </summary>
+        public string Name { get; set; }
+
+        /// <summary>
+        /// Optional type of the output, e.g. string, int, bool, array or object. Not verified when omitted
+        /// </summary>
+        public string Type { get; set; }
+
+        public override string ToString()
+        {
+            return string.IsNullOrEmpty(Type) ? Name : $"{Name} ({Type})";
+        }
+    }
+}
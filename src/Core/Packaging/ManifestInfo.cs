@@ -12,6 +12,21 @@ namespace Modm.Packaging
         public required string MainTemplate { get; set; }
         public required string DeploymentType { get; set; }
         public OfferInfo Offer { get; set; }
+
+        /// <summary>
+        /// Optional customer usage attribution GUID of the partner
+        /// </summary>
+        public string PartnerId { get; set; }
+
+        /// <summary>
+        /// The prerequisites that must be satisfied before the deployment is attempted
+        /// </summary>
+        public List<Prerequisite> Prerequisites { get; set; }
+
+        /// <summary>
+        /// The outputs the deployment must produce
+        /// </summary>
+        public List<ExpectedOutput> ExpectedOutputs { get; set; }
     }
 
     public record OfferInfo
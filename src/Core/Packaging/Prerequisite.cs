@@ -0,0 +1,68 @@
+﻿using System;
+namespace Modm.Packaging
+{
+    /// <summary>
+    /// A prerequisite declared in the manifest that must be satisfied before a deployment is attempted,
+    /// e.g. an existing virtual network, DNS zone, or a role assignment for the deploying identity
+    /// </summary>
+	public record Prerequisite
+	{
+        /// <summary>
+        /// The type of the prerequisite, see <see cref="PrerequisiteType"/>
+        /// </summary>
+        public string Type { get; set; }
+
+        /// <summary>
+        /// Optional description reported back when the prerequisite is unmet
+        /// </summary>
+        public string Description { get; set; }
+
+        /// <summary>
+        /// The fully qualified id of a resource that must exist. Used by <see cref="PrerequisiteType.Resource"/>
+        /// </summary>
+        public string ResourceId { get; set; }
+
+        /// <summary>
+        /// The resource provider namespace that must be registered, e.g. Microsoft.ContainerInstance.
+        /// Used by <see cref="PrerequisiteType.ResourceProvider"/>
+        /// </summary>
+        public string Namespace { get; set; }
+
+        /// <summary>
+        /// The scope of the role assignment. Used by <see cref="PrerequisiteType.RoleAssignment"/>
+        /// </summary>
+        public string Scope { get; set; }
+
+        /// <summary>
+        /// The role definition id (or name guid) the deploying identity must be assigned at <see cref="Scope"/>.
+        /// Used by <see cref="PrerequisiteType.RoleAssignment"/>
+        /// </summary>
+        public string RoleDefinitionId { get; set; }
+
+        public override string ToString()
+        {
+            if (!string.IsNullOrEmpty(Description))
+            {
+                return Description;
+            }
+
+            return Type switch
+            {
+                PrerequisiteType.Resource => $"resource '{ResourceId}' must exist",
+                PrerequisiteType.ResourceProvider => $"resource provider '{Namespace}' must be registered",
+                PrerequisiteType.RoleAssignment => $"role '{RoleDefinitionId}' must be assigned at scope '{Scope}'",
+                _ => $"{Type}"
+            };
+        }
+    }
+
+    /// <summary>
+    /// The supported types of prerequisites
+    /// </summary>
+    public static class PrerequisiteType
+    {
+        public const string Resource = "resource";
+        public const string ResourceProvider = "resourceProvider";
+        public const string RoleAssignment = "roleAssignment";
+    }
+}
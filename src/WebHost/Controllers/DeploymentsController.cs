@@ -2,6 +2,7 @@ using FluentValidation;
 using Microsoft.AspNetCore.Mvc;
 using Modm.Deployments;
 using Modm.Engine;
+using Modm.Packaging;
 
 namespace WebHost.Controllers
 {
@@ -11,11 +12,13 @@ namespace WebHost.Controllers
     {
         private readonly IValidator<StartDeploymentRequest> validator;
         private readonly IDeploymentEngine engine;
+        private readonly DeploymentExporter exporter;
 
-        public DeploymentsController(IValidator<StartDeploymentRequest> validator, IDeploymentEngine engine)
+        public DeploymentsController(IValidator<StartDeploymentRequest> validator, IDeploymentEngine engine, DeploymentExporter exporter)
         {
             this.validator = validator;
             this.engine = engine;
+            this.exporter = exporter;
         }
 
         public async Task<IResult> Get()
@@ -42,5 +45,34 @@ namespace WebHost.Controllers
             var result = await engine.Start(request, cancellationToken);
             return Results.Created("/deployments", result);
         }
+
+        /// <summary>
+        /// Gets the outputs of the completed deployment
+        /// </summary>
+        [HttpGet("outputs")]
+        public async Task<IResult> GetOutputsAsync()
+        {
+            var deployment = await engine.Get();
+            return Results.Json(deployment?.Outputs ?? new Dictionary<string, DeploymentOutput>());
+        }
+
+        /// <summary>
+        /// Exports the deployment definition as an installer package with secure parameters redacted
+        /// </summary>
+        [HttpGet("export")]
+        public async Task<IResult> ExportAsync(CancellationToken cancellationToken)
+        {
+            var deployment = await engine.Get();
+
+            if (deployment?.Definition?.WorkingDirectory == null)
+            {
+                return Results.NotFound();
+            }
+
+            var path = await exporter.ExportAsync(deployment.Definition, cancellationToken);
+            var stream = new FileStream(path, FileMode.Open, FileAccess.Read, FileShare.None, 4096, FileOptions.DeleteOnClose);
+
+            return Results.File(stream, "application/zip", PackageFile.FileName);
+        }
     }
 }
@@ -11,10 +11,12 @@ namespace WebHost.Controllers
     {
         private readonly string stripMarker = "-----------------";
         private readonly IDeploymentEngine engine;
+        private readonly SelfTest selfTest;
 
-        public DiagnosticsController(IDeploymentEngine engine)
+        public DiagnosticsController(IDeploymentEngine engine, SelfTest selfTest)
         {
             this.engine = engine;
+            this.selfTest = selfTest;
         }
 
         public async Task<IResult> Get()
@@ -28,5 +30,11 @@ namespace WebHost.Controllers
                     .Replace("jenkins", "***")
             }); 
         }
+
+        [HttpGet("selftest")]
+        public async Task<SelfTestReport> GetSelfTest(CancellationToken cancellationToken)
+        {
+            return await selfTest.RunAsync(cancellationToken);
+        }
     }
 }
\ No newline at end of file
@@ -3,6 +3,8 @@ using FluentValidation;
 using Microsoft.Extensions.Azure;
 using Microsoft.Extensions.Configuration;
 using Azure.Identity;
+using Azure.Core;
+using Modm.Azure;
 using Modm.Extensions;
 using Modm.Deployments;
 
@@ -31,8 +33,9 @@ namespace Modm.WebHost
             services.AddControllers();
             services.AddAzureClients(clientBuilder =>
             {
-                clientBuilder.AddArmClient(configuration.GetSection("Azure"));
-                clientBuilder.UseCredential(new DefaultAzureCredential());
+                clientBuilder.AddArmClient(configuration.GetSection("Azure"))
+                    .ConfigureOptions((options, provider) => options.AddPolicy(new UserAgentPolicy(provider.GetRequiredService<PartnerAttribution>()), HttpPipelinePosition.PerRetry));
+                clientBuilder.UseDefaultAzureCredential(configuration);
             });
 
             services.AddMediatR(c =>
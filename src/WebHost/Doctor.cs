@@ -0,0 +1,34 @@
+﻿using Modm.Diagnostics;
+
+namespace Modm.WebHost
+{
+    /// <summary>
+    /// The doctor command, e.g. dotnet WebHost.dll doctor, which runs the <see cref="SelfTest"/> instead of the host
+    /// and prints a pass/fail report
+    /// </summary>
+	public static class Doctor
+	{
+        public const string CommandName = "doctor";
+
+        public static bool IsRequested(string[] args)
+        {
+            return args.Contains(CommandName, StringComparer.OrdinalIgnoreCase);
+        }
+
+        /// <summary>
+        /// Runs the self test
+        /// </summary>
+        /// <returns>The exit code, 0 if every check passed</returns>
+        public static async Task<int> RunAsync(IServiceProvider services, TextWriter output, CancellationToken cancellationToken = default)
+        {
+            var report = await services.GetRequiredService<SelfTest>().RunAsync(cancellationToken);
+
+            foreach (var line in report.GetLines())
+            {
+                await output.WriteLineAsync(line);
+            }
+
+            return report.Passed ? 0 : 1;
+        }
+    }
+}
@@ -16,10 +16,18 @@ builder.Services.AddCors(options =>
 builder.Services.AddSingleton<IAzureResourceManagerClient, AzureResourceManagerClient>();
 
 builder.Services.AddJwtBearerAuthentication(builder.Configuration);
+builder.Services.AddIpAllowlist(builder.Configuration);
 builder.Configuration.AddAppConfigurationSafely(builder.Environment);
 
 var app = builder.Build();
 
+// runs the self test instead of the host, e.g. dotnet WebHost.dll doctor
+if (Doctor.IsRequested(args))
+{
+    Environment.ExitCode = await Doctor.RunAsync(app.Services, Console.Out);
+    return;
+}
+
 // Configure the HTTP request pipeline.
 if (!app.Environment.IsDevelopment())
 {
@@ -27,6 +35,7 @@ if (!app.Environment.IsDevelopment())
     app.UseHsts();
 }
 
+app.UseIpAllowlist();
 app.UseCors("AllowLocal");
 
 app.UseAuthentication();
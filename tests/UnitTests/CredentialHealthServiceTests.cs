@@ -0,0 +1,48 @@
+﻿using Azure.Identity;
+using Azure.ResourceManager;
+using Azure.ResourceManager.Resources;
+using Microsoft.Extensions.Logging;
+using Modm.Azure;
+using NSubstitute;
+using NSubstitute.ExceptionExtensions;
+
+namespace Modm.Tests.UnitTests
+{
+	public class CredentialHealthServiceTests
+	{
+        [Fact]
+        public void should_be_unhealthy_before_first_check()
+        {
+            var service = new CredentialHealthService(Substitute.For<ArmClient>(), Substitute.For<ILogger<CredentialHealthService>>());
+
+            Assert.False(service.GetStatus().IsHealthy);
+            Assert.Null(service.GetStatus().LastChecked);
+        }
+
+        [Fact]
+        public async Task should_be_healthy_when_token_is_acquired()
+        {
+            var client = Substitute.For<ArmClient>();
+            client.GetDefaultSubscriptionAsync(default).ReturnsForAnyArgs(Substitute.For<SubscriptionResource>());
+
+            var service = new CredentialHealthService(client, Substitute.For<ILogger<CredentialHealthService>>());
+            var status = await service.CheckAsync(default);
+
+            Assert.True(status.IsHealthy);
+            Assert.NotNull(status.LastChecked);
+        }
+
+        [Fact]
+        public async Task should_be_unhealthy_when_credential_fails()
+        {
+            var client = Substitute.For<ArmClient>();
+            client.GetDefaultSubscriptionAsync(default).ThrowsAsyncForAnyArgs(new AuthenticationFailedException("the token has expired"));
+
+            var service = new CredentialHealthService(client, Substitute.For<ILogger<CredentialHealthService>>());
+            var status = await service.CheckAsync(default);
+
+            Assert.False(status.IsHealthy);
+            Assert.Contains("the token has expired", status.Message);
+        }
+	}
+}
@@ -98,6 +98,19 @@ namespace Modm.Tests.UnitTests
             });
         }
 
+        [Fact]
+        public async Task should_list_resources_to_delete_without_deleting()
+        {
+            var resources = await processor.ListResourcesToDeleteAsync(resourceGroupName);
+
+            Assert.Equal(DeleteProcessor.ResourceTypes.Count, resources.Count);
+            Assert.Equal(new ResourceType("Microsoft.Compute/virtualMachines"), ResourceIdentifier.Parse(resources[0]).ResourceType);
+
+            await mediator.DidNotReceiveWithAnyArgs()
+                          .Send(Arg.Any<IRequest<DeleteResourceResult>>(),
+                                Arg.Any<CancellationToken>());
+        }
+
         protected override void ConfigureServices()
         {
             ConfigureMocks(c =>
@@ -125,7 +138,7 @@ namespace Modm.Tests.UnitTests
             {
             }
 
-            protected override Task<List<GenericResource>> GetResourcesToDeleteAsync(string resourceGroupName)
+            protected override Task<List<GenericResource>> GetResourcesToDeleteAsync(string resourceGroupName, CancellationToken cancellationToken = default)
             {
                 ReceivedResourceGroup = resourceGroupName;
                 return Task.FromResult(ResourceTypes.Select(type => FakeGenericResource.New(r =>
@@ -0,0 +1,73 @@
+﻿using System.IO.Compression;
+using System.Text.Json;
+using Modm.Compression;
+using Modm.Deployments;
+using Modm.Tests.Utils;
+
+namespace Modm.Tests.UnitTests
+{
+    public class DeploymentExporterTests
+    {
+        private readonly DeploymentExporter exporter;
+
+        public DeploymentExporterTests()
+        {
+            exporter = new DeploymentExporter(new ParametersFileFactory(), new DirectoryZipper());
+        }
+
+        [Fact]
+        public async Task should_redact_sensitive_terraform_variables()
+        {
+            using var workingDirectory = Test.Directory<DeploymentExporterTests>();
+            ZipFile.ExtractToDirectory(Test.DataFile.Get("installer.zip").FullName, workingDirectory.FullName);
+
+            var path = await exporter.ExportAsync(new DeploymentDefinition
+            {
+                WorkingDirectory = workingDirectory.FullName,
+                MainTemplatePath = "main.tf",
+                DeploymentType = DeploymentType.Terraform,
+                Parameters = new Dictionary<string, object>
+                {
+                    { "sql_admin_username", "admin" },
+                    { "sql_admin_password", "P@ssw0rd" }
+                }
+            });
+
+            using var archive = ZipFile.OpenRead(path);
+            using var stream = archive.GetEntry(TerraformParametersFile.FileName)!.Open();
+            var parameters = await JsonSerializer.DeserializeAsync<Dictionary<string, string>>(stream);
+
+            Assert.Equal("admin", parameters!["sql_admin_username"]);
+            Assert.Equal(DeploymentExporter.RedactedValue, parameters["sql_admin_password"]);
+            Assert.NotNull(archive.GetEntry("manifest.json"));
+
+            File.Delete(path);
+        }
+
+        [Fact]
+        public async Task should_exclude_terraform_state_and_outputs()
+        {
+            using var workingDirectory = Test.Directory<DeploymentExporterTests>();
+            ZipFile.ExtractToDirectory(Test.DataFile.Get("installer.zip").FullName, workingDirectory.FullName);
+            File.WriteAllText(Path.Combine(workingDirectory.FullName, "terraform.tfstate"), "{}");
+            Directory.CreateDirectory(Path.Combine(workingDirectory.FullName, ".terraform"));
+            File.WriteAllText(Path.Combine(workingDirectory.FullName, ".terraform", "state"), "{}");
+            File.WriteAllText(Path.Combine(workingDirectory.FullName, DeploymentOutput.FileName), "{}");
+
+            var path = await exporter.ExportAsync(new DeploymentDefinition
+            {
+                WorkingDirectory = workingDirectory.FullName,
+                MainTemplatePath = "main.tf",
+                DeploymentType = DeploymentType.Terraform
+            });
+
+            using var archive = ZipFile.OpenRead(path);
+
+            Assert.DoesNotContain(archive.Entries, e => e.FullName.Contains("tfstate") || e.FullName.StartsWith(".terraform"));
+            Assert.Null(archive.GetEntry(DeploymentOutput.FileName));
+            Assert.NotNull(archive.GetEntry("main.tf"));
+
+            File.Delete(path);
+        }
+    }
+}
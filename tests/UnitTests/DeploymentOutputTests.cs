@@ -0,0 +1,37 @@
+﻿using Modm.Deployments;
+using Modm.Tests.Utils;
+
+namespace Modm.Tests.UnitTests
+{
+    public class DeploymentOutputTests
+    {
+        [Fact]
+        public async Task should_read_outputs_written_by_deployment_job()
+        {
+            using var workingDirectory = Test.Directory<DeploymentOutputTests>();
+            await File.WriteAllTextAsync(Path.Combine(workingDirectory.FullName, DeploymentOutput.FileName), """
+            {
+                "hostname": { "type": "String", "value": "contoso.azurewebsites.net" },
+                "ports": { "type": ["list", "number"], "value": [80, 443] }
+            }
+            """);
+
+            var outputs = await DeploymentOutput.ReadAsync(workingDirectory.FullName);
+
+            Assert.Equal(2, outputs.Count);
+            Assert.Equal("contoso.azurewebsites.net", outputs["hostname"].Value.GetString());
+            Assert.Equal(2, outputs["ports"].Value.GetArrayLength());
+        }
+
+        [Fact]
+        public async Task should_be_empty_without_outputs()
+        {
+            using var workingDirectory = Test.Directory<DeploymentOutputTests>();
+            Assert.Empty(await DeploymentOutput.ReadAsync(workingDirectory.FullName));
+
+            // az deployment group show writes nothing when the template has no outputs
+            await File.WriteAllTextAsync(Path.Combine(workingDirectory.FullName, DeploymentOutput.FileName), string.Empty);
+            Assert.Empty(await DeploymentOutput.ReadAsync(workingDirectory.FullName));
+        }
+    }
+}
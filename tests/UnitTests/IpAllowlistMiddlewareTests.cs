@@ -0,0 +1,120 @@
+﻿using System.Net;
+using System.Text.Json;
+using Microsoft.AspNetCore.Builder;
+using Microsoft.AspNetCore.Http;
+using Microsoft.AspNetCore.HttpOverrides;
+using Microsoft.Extensions.Configuration;
+using Microsoft.Extensions.Logging.Abstractions;
+using Microsoft.Extensions.Options;
+using Modm.Configuration;
+using Modm.Deployments;
+using Modm.Http;
+using Modm.Tests.Utils;
+
+namespace Modm.Tests.UnitTests
+{
+	public class IpAllowlistMiddlewareTests
+	{
+        [Theory]
+        [InlineData("203.0.113.10", true)]
+        [InlineData("203.0.114.10", false)]
+        [InlineData("198.51.100.7", true)]
+        [InlineData("198.51.100.8", false)]
+        [InlineData("127.0.0.1", true)]
+        [InlineData("::ffff:203.0.113.10", true)]
+        public void should_allow_addresses_in_configured_ranges(string address, bool expected)
+        {
+            var middleware = Create("203.0.113.0/24", "198.51.100.7");
+            Assert.Equal(expected, middleware.IsAllowed(IPAddress.Parse(address)));
+        }
+
+        [Fact]
+        public void should_allow_all_when_no_ranges_configured()
+        {
+            var middleware = Create();
+
+            Assert.True(middleware.IsAllowed(IPAddress.Parse("192.0.2.1")));
+            Assert.True(middleware.IsAllowed(null!));
+        }
+
+        [Fact]
+        public async Task should_reject_with_forbidden()
+        {
+            var nextCalled = false;
+            var middleware = Create(_ => { nextCalled = true; return Task.CompletedTask; }, "203.0.113.0/24");
+
+            var context = new DefaultHttpContext();
+            context.Connection.RemoteIpAddress = IPAddress.Parse("192.0.2.1");
+
+            await middleware.InvokeAsync(context);
+
+            Assert.False(nextCalled);
+            Assert.Equal(StatusCodes.Status403Forbidden, context.Response.StatusCode);
+        }
+
+        [Theory]
+        [InlineData("::ffff:172.30.0.10", "203.0.113.10", true)]
+        [InlineData("172.30.0.10", "192.0.2.1", false)]
+        [InlineData("10.0.0.5", "203.0.113.10", false)]
+        public async Task should_only_trust_forwarded_address_from_known_proxies(string proxy, string forwardedFor, bool expected)
+        {
+            var nextCalled = false;
+            var options = new IpAllowlistOptions
+            {
+                AllowedRanges = new List<string> { "203.0.113.0/24" },
+                KnownProxies = new List<string> { "172.30.0.10" }
+            };
+
+            var forwardedHeaders = new ForwardedHeadersOptions();
+            IpAllowlistMiddleware.ConfigureForwardedHeaders(forwardedHeaders, options);
+
+            var allowlist = new IpAllowlistMiddleware(_ => { nextCalled = true; return Task.CompletedTask; }, Options.Create(options), NullLogger<IpAllowlistMiddleware>.Instance);
+            var middleware = new ForwardedHeadersMiddleware(allowlist.InvokeAsync, NullLoggerFactory.Instance, Options.Create(forwardedHeaders));
+
+            var context = new DefaultHttpContext();
+            context.Connection.RemoteIpAddress = IPAddress.Parse(proxy);
+            context.Request.Headers["X-Forwarded-For"] = forwardedFor;
+
+            await middleware.Invoke(context);
+
+            Assert.Equal(expected, nextCalled);
+        }
+
+        [Fact]
+        public async Task should_aggregate_rejections_into_single_audit_record()
+        {
+            using var homeDirectory = Test.Directory<IpAllowlistMiddlewareTests>();
+            var configuration = new ConfigurationBuilder()
+                .AddInMemoryCollection(new Dictionary<string, string?> { { EnvironmentVariable.Names.HomeDirectory, homeDirectory.FullName } })
+                .Build();
+
+            var auditFile = new AuditFile(configuration, NullLogger<AuditFile>.Instance);
+            var audit = new IpAllowlistAudit(auditFile, NullLogger<IpAllowlistAudit>.Instance);
+
+            for (var i = 0; i < 3; i++)
+            {
+                audit.Add(IPAddress.Parse("192.0.2.1"), "/api/deployments");
+            }
+
+            await audit.FlushAsync();
+            await audit.FlushAsync();
+
+            var records = await auditFile.ReadAsync();
+            Assert.Single(records);
+
+            var rejected = (JsonElement)records[0].AdditionalData["ipAllowlistRejected"];
+            Assert.Equal(3, rejected.GetProperty("addresses")[0].GetProperty("count").GetInt32());
+        }
+
+        private static IpAllowlistMiddleware Create(params string[] ranges)
+        {
+            return Create(_ => Task.CompletedTask, ranges);
+        }
+
+        private static IpAllowlistMiddleware Create(RequestDelegate next, params string[] ranges)
+        {
+            var options = Options.Create(new IpAllowlistOptions { AllowedRanges = ranges.ToList() });
+            return new IpAllowlistMiddleware(next, options, NullLogger<IpAllowlistMiddleware>.Instance);
+        }
+	}
+}
@@ -0,0 +1,58 @@
+﻿using System.Text.Json;
+using Azure.Security.KeyVault.Secrets;
+using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
+using Modm.Deployments;
+using Modm.Http;
+using NSubstitute;
+
+namespace Modm.Tests.UnitTests
+{
+	public class KeyVaultReferenceResolverTests
+	{
+        [Fact]
+        public void should_parse_references()
+        {
+            Assert.True(KeyVaultReferenceResolver.TryParse("@keyvault(https://contoso.vault.azure.net/secrets/adminPassword)", out var secretId));
+            Assert.Equal(new Uri("https://contoso.vault.azure.net/"), secretId.VaultUri);
+            Assert.Equal("adminPassword", secretId.Name);
+            Assert.Null(secretId.Version);
+
+            Assert.True(KeyVaultReferenceResolver.IsReference(JsonSerializer.Deserialize<JsonElement>("\"@keyvault(https://contoso.vault.azure.net/secrets/key/abc123)\"")));
+            Assert.False(KeyVaultReferenceResolver.IsReference("https://contoso.vault.azure.net/secrets/adminPassword"));
+            Assert.False(KeyVaultReferenceResolver.IsReference("@keyvault(https://contoso.vault.azure.net)"));
+            Assert.False(KeyVaultReferenceResolver.IsReference(42));
+        }
+
+        [Fact]
+        public async Task should_resolve_only_references()
+        {
+            var resolver = new KeyVaultReferenceResolverSpy();
+            var parameters = new Dictionary<string, object>
+            {
+                { "adminPassword", "@keyvault(https://contoso.vault.azure.net/secrets/adminPassword)" },
+                { "location", "eastus" }
+            };
+
+            var resolved = await resolver.ResolveAsync(parameters);
+
+            Assert.Equal("secret-adminPassword", resolved["adminPassword"]);
+            Assert.Equal("eastus", resolved["location"]);
+
+            // the original parameters keep the reference
+            Assert.StartsWith("@keyvault", (string)parameters["adminPassword"]);
+        }
+
+        class KeyVaultReferenceResolverSpy : KeyVaultReferenceResolver
+        {
+            public KeyVaultReferenceResolverSpy() : base(Options.Create(new OutboundHttpOptions()), Substitute.For<ILogger<KeyVaultReferenceResolver>>())
+            {
+            }
+
+            protected override Task<string> GetSecretAsync(KeyVaultSecretIdentifier secretId, CancellationToken cancellationToken)
+            {
+                return Task.FromResult($"secret-{secretId.Name}");
+            }
+        }
+	}
+}
@@ -0,0 +1,85 @@
+﻿using System.Net.Security;
+using System.Security.Authentication;
+using System.Security.Cryptography;
+using System.Security.Cryptography.X509Certificates;
+using Modm.Http;
+
+namespace Modm.Tests.UnitTests
+{
+	public class OutboundHttpHandlerTests
+	{
+        [Fact]
+        public void should_use_proxy_and_bypass_instance_metadata_service()
+        {
+            var handler = (SocketsHttpHandler)OutboundHttpHandler.Create(new OutboundHttpOptions
+            {
+                ProxyUrl = "http://proxy.contoso.com:3128"
+            });
+
+            Assert.True(handler.UseProxy);
+            Assert.Equal(new Uri("http://proxy.contoso.com:3128"), handler.Proxy!.GetProxy(new Uri("https://management.azure.com")));
+            Assert.True(handler.Proxy.IsBypassed(new Uri("http://169.254.169.254/metadata/instance")));
+            Assert.True(handler.Proxy.IsBypassed(new Uri("http://localhost:8080")));
+        }
+
+        [Fact]
+        public void should_set_minimum_tls_version()
+        {
+            var handler = (SocketsHttpHandler)OutboundHttpHandler.Create(new OutboundHttpOptions { MinimumTlsVersion = "1.3" });
+
+            Assert.Equal(SslProtocols.Tls13, handler.SslOptions.EnabledSslProtocols);
+            Assert.Throws<ArgumentOutOfRangeException>(() => OutboundHttpHandler.Create(new OutboundHttpOptions { MinimumTlsVersion = "1.0" }));
+        }
+
+        [Fact]
+        public void should_not_be_configured_by_default()
+        {
+            Assert.False(new OutboundHttpOptions().IsConfigured);
+        }
+
+        [Fact]
+        public void should_trust_chain_to_custom_root_through_intermediate_sent_by_server()
+        {
+            using var root = CreateCertificate("Contoso Proxy Root", null, isAuthority: true);
+            using var intermediate = CreateCertificate("Contoso Proxy Intermediate", root, isAuthority: true);
+            using var leaf = CreateCertificate("management.azure.com", intermediate, isAuthority: false);
+
+            // the chain of the validation callback holds the intermediate the server sent as an element, not in its extra store
+            using var chain = new X509Chain();
+            chain.ChainPolicy.RevocationMode = X509RevocationMode.NoCheck;
+            chain.ChainPolicy.ExtraStore.Add(intermediate);
+            chain.Build(leaf);
+            chain.ChainPolicy.ExtraStore.Clear();
+
+            var roots = new X509Certificate2Collection(root);
+
+            Assert.True(OutboundHttpHandler.IsTrusted(roots, leaf, chain, SslPolicyErrors.RemoteCertificateChainErrors));
+            Assert.False(OutboundHttpHandler.IsTrusted(roots, leaf, null!, SslPolicyErrors.RemoteCertificateChainErrors));
+            Assert.False(OutboundHttpHandler.IsTrusted(new X509Certificate2Collection(), leaf, chain, SslPolicyErrors.RemoteCertificateChainErrors));
+        }
+
+        private static X509Certificate2 CreateCertificate(string subject, X509Certificate2? issuer, bool isAuthority)
+        {
+            using var key = RSA.Create(2048);
+            var request = new CertificateRequest($"CN={subject}", key, HashAlgorithmName.SHA256, RSASignaturePadding.Pkcs1);
+            request.CertificateExtensions.Add(new X509BasicConstraintsExtension(isAuthority, false, 0, true));
+            request.CertificateExtensions.Add(new X509SubjectKeyIdentifierExtension(request.PublicKey, false));
+
+            if (isAuthority)
+            {
+                request.CertificateExtensions.Add(new X509KeyUsageExtension(X509KeyUsageFlags.KeyCertSign | X509KeyUsageFlags.CrlSign, true));
+            }
+
+            var notBefore = DateTimeOffset.UtcNow.AddDays(-1);
+            var notAfter = DateTimeOffset.UtcNow.AddDays(30);
+
+            if (issuer == null)
+            {
+                return request.CreateSelfSigned(notBefore, notAfter);
+            }
+
+            using var certificate = request.Create(issuer, notBefore, notAfter, RandomNumberGenerator.GetBytes(8));
+            return certificate.CopyWithPrivateKey(key);
+        }
+	}
+}
@@ -0,0 +1,84 @@
+﻿using System.Text.Json;
+using Modm.Deployments;
+using Modm.Packaging;
+
+namespace Modm.Tests.UnitTests
+{
+    public class OutputContractVerifierTests
+    {
+        [Fact]
+        public void should_be_satisfied_when_expected_outputs_exist_with_matching_types()
+        {
+            var outputs = Outputs("""
+            {
+                "hostname": { "type": "String", "value": "contoso.azurewebsites.net" },
+                "ports": { "type": ["list", "number"], "value": [80, 443] },
+                "count": { "type": "number", "value": 2 }
+            }
+            """);
+
+            var violations = OutputContractVerifier.Verify(new List<ExpectedOutput>
+            {
+                new ExpectedOutput { Name = "hostname", Type = "string" },
+                new ExpectedOutput { Name = "ports", Type = "array" },
+                new ExpectedOutput { Name = "count", Type = "int" }
+            }, outputs);
+
+            Assert.Empty(violations);
+        }
+
+        [Fact]
+        public void should_report_missing_outputs()
+        {
+            var outputs = Outputs("""{ "hostname": { "type": "String", "value": "contoso" } }""");
+
+            var violations = OutputContractVerifier.Verify(new List<ExpectedOutput>
+            {
+                new ExpectedOutput { Name = "hostname" },
+                new ExpectedOutput { Name = "endpoint" }
+            }, outputs);
+
+            Assert.Single(violations);
+            Assert.Contains("endpoint", violations[0]);
+        }
+
+        [Fact]
+        public void should_report_type_mismatch()
+        {
+            var outputs = Outputs("""{ "port": { "type": "String", "value": "80" } }""");
+
+            var violations = OutputContractVerifier.Verify(new List<ExpectedOutput>
+            {
+                new ExpectedOutput { Name = "port", Type = "int" }
+            }, outputs);
+
+            Assert.Single(violations);
+            Assert.Contains("'String'", violations[0]);
+        }
+
+        [Fact]
+        public void should_be_satisfied_by_sensitive_outputs_without_values()
+        {
+            var outputs = Outputs("""{ "connectionString": { "sensitive": true, "type": "string", "value": null } }""");
+
+            var violations = OutputContractVerifier.Verify(new List<ExpectedOutput>
+            {
+                new ExpectedOutput { Name = "connectionString", Type = "securestring" }
+            }, outputs);
+
+            Assert.Empty(violations);
+            Assert.True(outputs["connectionString"].Sensitive);
+        }
+
+        [Fact]
+        public void should_be_satisfied_without_expected_outputs()
+        {
+            Assert.Empty(OutputContractVerifier.Verify(null, null));
+        }
+
+        private static Dictionary<string, DeploymentOutput> Outputs(string json)
+        {
+            return JsonSerializer.Deserialize<Dictionary<string, DeploymentOutput>>(json)!;
+        }
+    }
+}
@@ -0,0 +1,33 @@
+﻿using System;
+using Modm.Deployments;
+
+namespace Modm.Tests.UnitTests
+{
+	public class ParameterChangeTests
+	{
+        [Fact]
+        public void should_report_added_removed_and_modified_parameters()
+        {
+            var previous = new Dictionary<string, object> { { "location", "eastus" }, { "sku", "Standard_LRS" }, { "count", 1L } };
+            var current = new Dictionary<string, object> { { "location", "westus" }, { "count", 1L }, { "name", "app" } };
+
+            var changes = ParameterChange.Compare(previous, current);
+
+            Assert.Equal(new[] { "location", "name", "sku" }, changes.Select(c => c.Name));
+            Assert.Equal("eastus", changes[0].PreviousValue);
+            Assert.Equal("westus", changes[0].Value);
+            Assert.Null(changes[1].PreviousValue);
+            Assert.Null(changes[2].Value);
+        }
+
+        [Fact]
+        public void should_compare_nested_values_by_content()
+        {
+            var previous = new Dictionary<string, object> { { "tags", new Dictionary<string, object> { { "env", "dev" } } } };
+            var current = new Dictionary<string, object> { { "tags", new Dictionary<string, object> { { "env", "dev" } } } };
+
+            Assert.Empty(ParameterChange.Compare(previous, current));
+            Assert.Empty(ParameterChange.Compare(null, null));
+        }
+	}
+}
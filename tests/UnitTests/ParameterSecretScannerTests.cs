@@ -0,0 +1,38 @@
+﻿using System.Text.Json;
+using Modm.Deployments;
+
+namespace Modm.Tests.UnitTests
+{
+	public class ParameterSecretScannerTests
+	{
+        [Fact]
+        public void should_find_secrets_in_parameters_that_are_not_secure()
+        {
+            var parameters = new Dictionary<string, object>
+            {
+                { "storageConnection", "DefaultEndpointsProtocol=https;AccountName=contoso;AccountKey=c2VjcmV0a2V5;EndpointSuffix=core.windows.net" },
+                { "settings", new { token = "ghp_" + new string('a', 36) } },
+                { "location", "eastus" }
+            };
+
+            var findings = ParameterSecretScanner.Scan(parameters, new HashSet<string>());
+
+            Assert.Equal(2, findings.Count);
+            Assert.Contains("'settings'", findings[0]);
+            Assert.Contains("'storageConnection'", findings[1]);
+            Assert.DoesNotContain(findings, f => f.Contains("c2VjcmV0a2V5"));
+        }
+
+        [Fact]
+        public void should_not_scan_secure_parameters()
+        {
+            var parameters = new Dictionary<string, object>
+            {
+                { "adminPassword", JsonSerializer.Deserialize<JsonElement>("\"Password=hunter22\"") }
+            };
+
+            Assert.Single(ParameterSecretScanner.Scan(parameters, new HashSet<string>()));
+            Assert.Empty(ParameterSecretScanner.Scan(parameters, new HashSet<string> { "adminPassword" }));
+        }
+	}
+}
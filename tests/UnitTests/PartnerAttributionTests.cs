@@ -0,0 +1,34 @@
+﻿using Microsoft.Extensions.Configuration;
+using Microsoft.Extensions.Logging.Abstractions;
+using Modm.Azure;
+using Modm.Configuration;
+using Modm.Deployments;
+using Modm.Tests.Utils;
+
+namespace Modm.Tests.UnitTests
+{
+    public class PartnerAttributionTests
+    {
+        [Fact]
+        public async Task should_restore_attribution_of_persisted_deployment()
+        {
+            using var homeDirectory = Test.Directory<PartnerAttributionTests>();
+            var configuration = new ConfigurationBuilder()
+                .AddInMemoryCollection(new Dictionary<string, string?> { { EnvironmentVariable.Names.HomeDirectory, homeDirectory.FullName } })
+                .Build();
+
+            var deploymentFile = new DeploymentFile(configuration, NullLogger<DeploymentFile>.Instance);
+            await deploymentFile.WriteAsync(new Deployment
+            {
+                Definition = new DeploymentDefinition { PartnerId = "00000000-0000-0000-0000-000000000001" }
+            }, default);
+
+            var attribution = new PartnerAttribution(deploymentFile, NullLogger<PartnerAttribution>.Instance);
+            Assert.Equal($"modm/{PartnerAttribution.Version}", attribution.UserAgent);
+
+            await attribution.StartAsync(default);
+
+            Assert.Equal($"pid-00000000-0000-0000-0000-000000000001 modm/{PartnerAttribution.Version}", attribution.UserAgent);
+        }
+    }
+}
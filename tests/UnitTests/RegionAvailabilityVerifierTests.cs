@@ -0,0 +1,101 @@
+﻿using System;
+using Modm.Deployments;
+
+namespace Modm.Tests.UnitTests
+{
+	public class RegionAvailabilityVerifierTests
+	{
+        [Fact]
+        public void should_get_resource_types_including_child_and_nested_resources()
+        {
+            var template = """
+            {
+                "resources": [
+                    {
+                        "type": "Microsoft.Web/sites",
+                        "resources": [ { "type": "config" } ]
+                    },
+                    {
+                        "type": "Microsoft.Resources/deployments",
+                        "properties": {
+                            "template": {
+                                "resources": [ { "type": "Microsoft.Storage/storageAccounts" } ]
+                            }
+                        }
+                    },
+                    { "type": "[parameters('type')]" }
+                ]
+            }
+            """;
+
+            var types = RegionAvailabilityVerifier.GetResourceTypes(template);
+
+            Assert.Equal(new[]
+            {
+                "Microsoft.Resources/deployments",
+                "Microsoft.Storage/storageAccounts",
+                "Microsoft.Web/sites",
+                "Microsoft.Web/sites/config"
+            }, types.OrderBy(t => t));
+        }
+
+        [Fact]
+        public void should_get_resource_types_of_symbolic_name_templates()
+        {
+            var template = """
+            {
+                "languageVersion": "2.0",
+                "resources": {
+                    "storage": { "type": "Microsoft.Storage/storageAccounts" }
+                }
+            }
+            """;
+
+            Assert.Single(RegionAvailabilityVerifier.GetResourceTypes(template), "Microsoft.Storage/storageAccounts");
+        }
+
+        [Fact]
+        public void should_get_virtual_machine_sizes_resolving_parameters()
+        {
+            var template = """
+            {
+                "parameters": {
+                    "vmSize": { "type": "string", "defaultValue": "Standard_B2s" },
+                    "scaleSetSize": { "type": "string" }
+                },
+                "resources": [
+                    {
+                        "type": "Microsoft.Compute/virtualMachines",
+                        "properties": { "hardwareProfile": { "vmSize": "[parameters('vmSize')]" } }
+                    },
+                    {
+                        "type": "Microsoft.Compute/virtualMachineScaleSets",
+                        "sku": { "name": "[parameters('scaleSetSize')]" }
+                    },
+                    {
+                        "type": "Microsoft.Compute/virtualMachines",
+                        "properties": { "hardwareProfile": { "vmSize": "[variables('size')]" } }
+                    },
+                    {
+                        "type": "Microsoft.Resources/deployments",
+                        "properties": {
+                            "template": {
+                                "resources": [
+                                    {
+                                        "type": "Microsoft.Compute/virtualMachines",
+                                        "properties": { "hardwareProfile": { "vmSize": "Standard_D2s_v5" } }
+                                    }
+                                ]
+                            }
+                        }
+                    }
+                ]
+            }
+            """;
+
+            var sizes = RegionAvailabilityVerifier.GetVirtualMachineSizes(template, new Dictionary<string, object> { { "scaleSetSize", "Standard_F4s_v2" } });
+
+            Assert.Equal(new[] { "Standard_B2s", "Standard_D2s_v5", "Standard_F4s_v2" }, sizes.OrderBy(s => s));
+        }
+	}
+}
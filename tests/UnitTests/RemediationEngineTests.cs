@@ -0,0 +1,56 @@
+﻿using System;
+using Modm.Diagnostics;
+
+namespace Modm.Tests.UnitTests
+{
+	public class RemediationEngineTests
+	{
+        private readonly RemediationEngine engine;
+
+        public RemediationEngineTests()
+		{
+            engine = new RemediationEngine();
+		}
+
+        [Fact]
+        public void should_suggest_provider_registration()
+        {
+            var logs = "ERROR: (MissingSubscriptionRegistration) The subscription is not registered to use namespace 'Microsoft.ContainerInstance'.";
+
+            var remediations = engine.Evaluate(logs).ToList();
+
+            Assert.Single(remediations);
+            Assert.Equal("MissingSubscriptionRegistration", remediations[0].Category);
+            Assert.Contains("az provider register --namespace Microsoft.ContainerInstance", remediations[0].Description);
+        }
+
+        [Fact]
+        public void should_suggest_role_assignment_with_action()
+        {
+            var logs = "The client 'abc' with object id 'abc' does not have authorization to perform action 'Microsoft.Storage/storageAccounts/write' over scope '/subscriptions/123'";
+
+            var remediation = engine.Evaluate(logs).Single();
+
+            Assert.Equal("AuthorizationFailed", remediation.Category);
+            Assert.Contains("'Microsoft.Storage/storageAccounts/write'", remediation.Description);
+        }
+
+        [Fact]
+        public void should_return_one_remediation_per_category()
+        {
+            var logs = "QuotaExceeded: Operation could not be completed as it results in exceeding approved standardDSv3Family Cores quota.\nSkuNotAvailable";
+
+            var categories = engine.Evaluate(logs).Select(r => r.Category).ToList();
+
+            Assert.Equal(new[] { "QuotaExceeded", "SkuNotAvailable" }, categories);
+        }
+
+        [Fact]
+        public void should_return_empty_when_nothing_matches()
+        {
+            Assert.Empty(engine.Evaluate("Finished: SUCCESS"));
+            Assert.Empty(engine.Evaluate(string.Empty));
+            Assert.Empty(engine.Evaluate(null));
+        }
+	}
+}
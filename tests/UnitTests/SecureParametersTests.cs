@@ -0,0 +1,44 @@
+﻿using Modm.Deployments;
+using Modm.Tests.Utils;
+
+namespace Modm.Tests.UnitTests
+{
+    public class SecureParametersTests
+    {
+        [Fact]
+        public async Task should_read_sensitive_variables_with_nested_blocks()
+        {
+            using var templateDirectory = Test.Directory<SecureParametersTests>();
+            var mainTemplatePath = Path.Combine(templateDirectory.FullName, "main.tf");
+            await File.WriteAllTextAsync(mainTemplatePath, """
+            variable "db_password" {
+              type = object({
+                value = string
+              })
+              validation {
+                condition     = length(var.db_password.value) >= 12
+                error_message = "The password must be at least 12 characters, not ${length(var.db_password.value)}."
+              }
+              sensitive = true
+            }
+
+            variable "location" {
+              # sensitive = true
+              description = "sensitive = true"
+              validation {
+                condition = contains(["eastus", "westus"], var.location)
+                error_message = "Unsupported location."
+              }
+            }
+
+            variable "api_key" {
+              sensitive = true
+            }
+            """);
+
+            var names = await SecureParameters.GetNamesAsync(DeploymentType.Terraform, mainTemplatePath);
+
+            Assert.Equal(new[] { "api_key", "db_password" }, names.OrderBy(name => name));
+        }
+    }
+}
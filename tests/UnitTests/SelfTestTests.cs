@@ -0,0 +1,78 @@
+﻿using Azure.ResourceManager;
+using Azure.ResourceManager.Resources;
+using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
+using Modm.Azure;
+using Modm.Diagnostics;
+using Modm.Engine;
+using Modm.Jenkins;
+using Modm.Jenkins.Client;
+using Modm.Tests.Fakes;
+using NSubstitute;
+using NSubstitute.ExceptionExtensions;
+
+namespace Modm.Tests.UnitTests
+{
+	public class SelfTestTests
+	{
+        [Fact]
+        public async Task should_report_each_check()
+        {
+            var jenkinsOptions = Options.Create(new JenkinsOptions { BaseUrl = "http://jenkins:8080", UserName = "admin", Password = "", ApiToken = "" });
+
+            var managedIdentityService = Substitute.For<IManagedIdentityService>();
+            managedIdentityService.IsAccessibleAsync(default).ReturnsForAnyArgs(true);
+
+            var client = Substitute.For<ArmClient>();
+            client.GetDefaultSubscriptionAsync(default).ReturnsForAnyArgs(Substitute.For<SubscriptionResource>());
+
+            var selfTest = new SelfTest(
+                jenkinsOptions,
+                managedIdentityService,
+                new CredentialHealthService(client, Substitute.For<ILogger<CredentialHealthService>>()),
+                new JenkinsReadinessService(new HttpClient(new FakeHttpMessageHandler(System.Net.HttpStatusCode.ServiceUnavailable)), Substitute.For<JenkinsClientFactory>(), jenkinsOptions, Substitute.For<ILogger<JenkinsReadinessService>>()),
+                Substitute.For<ILogger<SelfTest>>());
+
+            var report = await selfTest.RunAsync();
+
+            Assert.False(report.Passed);
+            Assert.Equal(new[] { "configuration", "managedIdentity", "azureResourceManager", "jenkins" }, report.Checks.Select(c => c.Name));
+
+            Assert.False(report.Checks[0].Passed);
+            Assert.Contains("Jenkins:Password", report.Checks[0].Message);
+            Assert.True(report.Checks[1].Passed);
+            Assert.True(report.Checks[2].Passed);
+
+            // jenkins is still starting
+            Assert.False(report.Checks[3].Passed);
+            Assert.Contains("503", report.Checks[3].Message);
+
+            Assert.Contains("[FAIL] configuration", report.GetLines().First());
+        }
+
+        [Fact]
+        public async Task should_redact_jenkins_details_of_failed_checks()
+        {
+            var jenkinsOptions = Options.Create(new JenkinsOptions { BaseUrl = "http://jenkins:8080", UserName = "admin", Password = "secret", ApiToken = "" });
+
+            var managedIdentityService = Substitute.For<IManagedIdentityService>();
+            managedIdentityService.IsAccessibleAsync(default).ThrowsAsyncForAnyArgs(new HttpRequestException("Connection refused (http://jenkins:8080/login)"));
+
+            var client = Substitute.For<ArmClient>();
+            client.GetDefaultSubscriptionAsync(default).ReturnsForAnyArgs(Substitute.For<SubscriptionResource>());
+
+            var selfTest = new SelfTest(
+                jenkinsOptions,
+                managedIdentityService,
+                new CredentialHealthService(client, Substitute.For<ILogger<CredentialHealthService>>()),
+                new JenkinsReadinessService(new HttpClient(new FakeHttpMessageHandler()), Substitute.For<JenkinsClientFactory>(), jenkinsOptions, Substitute.For<ILogger<JenkinsReadinessService>>()),
+                Substitute.For<ILogger<SelfTest>>());
+
+            var report = await selfTest.RunAsync();
+
+            Assert.False(report.Checks[1].Passed);
+            Assert.DoesNotContain("jenkins", report.Checks[1].Message, StringComparison.OrdinalIgnoreCase);
+            Assert.DoesNotContain("8080", report.Checks[1].Message);
+        }
+	}
+}
@@ -1,8 +1,12 @@
-﻿using Microsoft.Extensions.DependencyInjection;
+﻿using Azure.Security.KeyVault.Secrets;
+using Microsoft.Extensions.DependencyInjection;
+using Microsoft.Extensions.Logging;
+using Microsoft.Extensions.Options;
 using Modm.Deployments;
 using Modm.Engine;
 using Modm.Engine.Pipelines;
 using Modm.Extensions;
+using Modm.Http;
 using Modm.Azure;
 using Modm.Tests.Utils;
 using Modm.Packaging;
@@ -50,6 +54,106 @@ namespace Modm.Tests.UnitTests
 
             Assert.Single(result.Errors);
             Assert.Equal("Deployment is not startable", result.Errors.First());
+
+            this.With<TemplateParametersVerifier>(v => v.DidNotReceiveWithAnyArgs().VerifyAsync(null!, null!, default));
+            this.With<PrerequisitesVerifier>(v => v.DidNotReceiveWithAnyArgs().VerifyAsync(null!, default));
+            this.With<RegionAvailabilityVerifier>(v => v.DidNotReceiveWithAnyArgs().VerifyAsync(null!, default));
+        }
+
+        [Fact]
+        public async Task should_not_submit_when_prerequisites_are_unmet()
+        {
+            this.With<IDeploymentRepository>(r => r.Get().ReturnsForAnyArgs(new Deployment
+            {
+                IsStartable = true,
+                Definition = new DeploymentDefinition()
+            }));
+            this.With<PrerequisitesVerifier>(v => v.VerifyAsync(null!, default).ReturnsForAnyArgs(new List<string>
+            {
+                "Unmet prerequisite: virtual network must exist"
+            }));
+
+            var result = await pipeline.Execute(request);
+
+            Assert.Single(result.Errors);
+            Assert.Equal(-1, result.Deployment.Id);
+            this.With<JenkinsClientFactory>(factory => factory.DidNotReceive().Create());
+        }
+
+        [Fact]
+        public async Task should_audit_parameter_secret_warnings()
+        {
+            var secretRequest = request with
+            {
+                Parameters = new Dictionary<string, object>
+                {
+                    { "storageConnection", "DefaultEndpointsProtocol=https;AccountName=contoso;AccountKey=c2VjcmV0a2V5" }
+                }
+            };
+
+            await pipeline.Execute(secretRequest);
+
+            var auditRecords = await Provider.GetRequiredService<AuditFile>().ReadAsync();
+            Assert.Contains(auditRecords, r => r.AdditionalData.ContainsKey("parameterSecretWarnings"));
+        }
+
+        [Fact]
+        public async Task should_redact_secure_parameters_before_persisting()
+        {
+            const string password = "P@ssw0rd-never-persisted";
+            var secureRequest = request with
+            {
+                Parameters = new Dictionary<string, object>
+                {
+                    { "sql_admin_password", password },
+                    { "sql_admin_username", "admin" }
+                }
+            };
+
+            await pipeline.Execute(secureRequest);
+
+            var deployment = await Provider.GetRequiredService<DeploymentFile>().ReadAsync();
+            Assert.Equal(SecureParameters.RedactedValue, deployment.Definition.Parameters["sql_admin_password"]);
+            Assert.Equal("admin", deployment.Definition.Parameters["sql_admin_username"]);
+
+            var auditRecords = await Provider.GetRequiredService<AuditFile>().ReadAsync();
+            Assert.DoesNotContain(password, System.Text.Json.JsonSerializer.Serialize(auditRecords));
+
+            // only the parameters file used by the deployment job receives the value
+            Assert.Contains(password, await File.ReadAllTextAsync(deployment.Definition.ParametersFilePath));
+        }
+
+        [Fact]
+        public async Task should_record_changes_of_key_vault_resolved_secure_values()
+        {
+            var secureRequest = request with
+            {
+                Parameters = new Dictionary<string, object>
+                {
+                    { "sql_admin_password", "@keyvault(https://contoso.vault.azure.net/secrets/sqlAdminPassword)" },
+                    { "sql_admin_username", "admin" }
+                }
+            };
+            var resolver = (KeyVaultReferenceResolverStub)Provider.GetRequiredService<KeyVaultReferenceResolver>();
+            var deploymentFile = Provider.GetRequiredService<DeploymentFile>();
+
+            resolver.Secret = "first-password";
+            await pipeline.Execute(secureRequest);
+
+            // the reference is unchanged, but the secret it resolves to was rotated
+            resolver.Secret = "rotated-password";
+            await pipeline.Execute(secureRequest);
+
+            var deployment = await deploymentFile.ReadAsync();
+            var change = Assert.Single(deployment.ParameterChanges);
+            Assert.Equal("sql_admin_password", change.Name);
+            Assert.Equal(SecureParameters.RedactedValue, change.PreviousValue.ToString());
+            Assert.Equal(SecureParameters.RedactedValue, change.Value.ToString());
+
+            await pipeline.Execute(secureRequest);
+
+            deployment = await deploymentFile.ReadAsync();
+            Assert.Empty(deployment.ParameterChanges);
         }
 
         private StartDeploymentRequestPipeline GetPipeline()
@@ -75,18 +179,52 @@ namespace Modm.Tests.UnitTests
 
                     Services.AddScoped(x => instance);
                 });
+
+                m.Create<PrerequisitesVerifier>(instance =>
+                {
+                    instance.VerifyAsync(null!, default).ReturnsForAnyArgs(new List<string>());
+                    Services.AddSingleton(instance);
+                });
+
+                m.Create<RegionAvailabilityVerifier>(instance =>
+                {
+                    instance.VerifyAsync(null!, default).ReturnsForAnyArgs(new List<string>());
+                    Services.AddSingleton(instance);
+                });
+
+                m.Create<TemplateParametersVerifier>(instance =>
+                {
+                    instance.VerifyAsync(null!, null!, default).ReturnsForAnyArgs(new List<string>());
+                    Services.AddSingleton(instance);
+                });
             });
 
             Services.AddLogging();
             Services.AddSingleton<IMetadataService, LocalMetadataService>();
             Services.AddSingleton<IManagedIdentityService, LocalManagedIdentityService>();
             Services.AddSingleton<ParametersFileFactory>();
+            Services.AddSingleton<KeyVaultReferenceResolver, KeyVaultReferenceResolverStub>();
+            Services.AddSingleton<PartnerAttribution>();
             Services.AddScoped<DeploymentFile>();
             Services.AddScoped<AuditFile>();
 
             Services.AddMediatR(c => c.RegisterServicesFromAssemblyContaining<IDeploymentEngine>());
             Services.AddPipeline<IPipeline<StartDeploymentRequest, StartDeploymentResult>, StartDeploymentRequestPipeline>(c => c.AddStartDeploymentRequestPipeline());
         }
+
+        class KeyVaultReferenceResolverStub : KeyVaultReferenceResolver
+        {
+            public string Secret { get; set; } = string.Empty;
+
+            public KeyVaultReferenceResolverStub() : base(Options.Create(new OutboundHttpOptions()), Substitute.For<ILogger<KeyVaultReferenceResolver>>())
+            {
+            }
+
+            protected override Task<string> GetSecretAsync(KeyVaultSecretIdentifier secretId, CancellationToken cancellationToken)
+            {
+                return Task.FromResult(Secret);
+            }
+        }
     }
 }
 
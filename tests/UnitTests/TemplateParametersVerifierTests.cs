@@ -0,0 +1,68 @@
+﻿using System.Text.Json;
+using Modm.Deployments;
+
+namespace Modm.Tests.UnitTests
+{
+	public class TemplateParametersVerifierTests
+	{
+        private readonly JsonElement declarations = JsonDocument.Parse("""
+            {
+                "location": { "type": "string", "allowedValues": [ "eastus", "westus" ] },
+                "name": { "type": "string", "minLength": 3, "maxLength": 8 },
+                "count": { "type": "int", "defaultValue": 1, "minValue": 1, "maxValue": 5 },
+                "enabled": { "type": "bool" }
+            }
+            """).RootElement;
+
+        [Fact]
+        public void should_pass_valid_parameters()
+        {
+            var parameters = new Dictionary<string, object>
+            {
+                { "location", "EastUS" },
+                { "name", "contoso" },
+                { "enabled", JsonSerializer.Deserialize<JsonElement>("true") }
+            };
+
+            Assert.Empty(TemplateParametersVerifier.Verify(declarations, parameters));
+        }
+
+        [Fact]
+        public void should_report_invalid_parameters()
+        {
+            var parameters = new Dictionary<string, object>
+            {
+                { "location", "northeurope" },
+                { "name", "ab" },
+                { "count", 10 },
+                { "unknown", "value" }
+            };
+
+            var errors = TemplateParametersVerifier.Verify(declarations, parameters);
+
+            Assert.Equal(new[]
+            {
+                "Parameter 'unknown' is not declared in the template.",
+                "Parameter 'location' must be one of: eastus, westus.",
+                "Parameter 'name' must have a length of at least 3.",
+                "Parameter 'count' must be at most 5.",
+                "Parameter 'enabled' is required."
+            }, errors);
+        }
+
+        [Fact]
+        public void should_report_type_mismatch()
+        {
+            var parameters = new Dictionary<string, object>
+            {
+                { "location", "eastus" },
+                { "name", "contoso" },
+                { "enabled", "yes" }
+            };
+
+            var errors = TemplateParametersVerifier.Verify(declarations, parameters);
+
+            Assert.Equal("Parameter 'enabled' must be of type bool.", Assert.Single(errors));
+        }
+	}
+}
@@ -7,9 +7,16 @@ namespace Modm.Tests.Fakes
 {
     public class FakeHttpMessageHandler : HttpMessageHandler
     {
+        private readonly HttpStatusCode statusCode;
+
+        public FakeHttpMessageHandler(HttpStatusCode statusCode = HttpStatusCode.OK)
+        {
+            this.statusCode = statusCode;
+        }
+
         protected override Task<HttpResponseMessage> SendAsync(HttpRequestMessage request, CancellationToken cancellationToken)
         {
-            return Task.FromResult(new HttpResponseMessage(HttpStatusCode.OK));
+            return Task.FromResult(new HttpResponseMessage(statusCode));
         }
     }
 